@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Leopold1975/banners_control/internal/bannerctl"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	err := bannerctl.Run(ctx, os.Args[1:], os.Stdin, os.Stdout)
+	if err == nil {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, err)
+
+	if errors.Is(err, bannerctl.ErrUsage) {
+		os.Exit(2) //nolint:gocritic
+	}
+
+	os.Exit(1)
+}
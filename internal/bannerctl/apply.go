@@ -0,0 +1,206 @@
+package bannerctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+)
+
+var ErrInvalidDir = errors.New("invalid banner directory")
+
+// Plan - изменения, после которых баннеры сервера совпадут с локальными.
+// Баннеры сопоставляются по external_key, баннеры сервера без ключа не
+// затрагиваются.
+type Plan struct {
+	Create    []models.Banner
+	Update    []models.Banner
+	Delete    []models.Banner
+	Unchanged int
+}
+
+func (p Plan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// Diff сравнивает локальные баннеры с баннерами сервера. Баннеры сервера,
+// которых нет локально, удаляются, только если prune.
+func Diff(local, remote []models.Banner, prune bool) Plan {
+	var plan Plan
+
+	byKey := make(map[string]models.Banner, len(remote))
+
+	for _, b := range remote {
+		if b.ExternalKey != "" {
+			byKey[b.ExternalKey] = b
+		}
+	}
+
+	seen := make(map[string]struct{}, len(local))
+
+	for _, l := range local {
+		seen[l.ExternalKey] = struct{}{}
+
+		r, ok := byKey[l.ExternalKey]
+
+		switch {
+		case !ok:
+			plan.Create = append(plan.Create, l)
+		case sameBanner(l, r):
+			plan.Unchanged++
+		default:
+			l.ID = r.ID
+			plan.Update = append(plan.Update, l)
+		}
+	}
+
+	if prune {
+		for _, r := range remote {
+			if _, ok := seen[r.ExternalKey]; r.ExternalKey != "" && !ok {
+				plan.Delete = append(plan.Delete, r)
+			}
+		}
+	}
+
+	sortByKey(plan.Create)
+	sortByKey(plan.Update)
+	sortByKey(plan.Delete)
+
+	return plan
+}
+
+func sameBanner(a, b models.Banner) bool {
+	if a.FeatureID != b.FeatureID || a.Active != b.Active {
+		return false
+	}
+
+	ta, tb := slices.Clone(a.Tags), slices.Clone(b.Tags)
+	slices.Sort(ta)
+	slices.Sort(tb)
+
+	return slices.Equal(ta, tb) && reflect.DeepEqual(a.Content, b.Content)
+}
+
+func sortByKey(banners []models.Banner) {
+	sort.Slice(banners, func(i, j int) bool {
+		return banners[i].ExternalKey < banners[j].ExternalKey
+	})
+}
+
+// LoadDir читает баннеры каталога и проверяет, что у каждого есть
+// уникальный external_key.
+func LoadDir(dir string) ([]models.Banner, error) {
+	files, err := ReadBannerDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	var (
+		banners []models.Banner
+		keys    = make(map[string]string)
+	)
+
+	for _, path := range paths {
+		for _, b := range files[path] {
+			if b.ExternalKey == "" {
+				return nil, fmt.Errorf("%w: %s: banner without external_key", ErrInvalidDir, path)
+			}
+
+			if first, ok := keys[b.ExternalKey]; ok {
+				return nil, fmt.Errorf("%w: %s: external_key %q already defined in %s",
+					ErrInvalidDir, path, b.ExternalKey, first)
+			}
+
+			keys[b.ExternalKey] = path
+			banners = append(banners, b)
+		}
+	}
+
+	return banners, nil
+}
+
+// PlanDir строит план для каталога dir.
+func (c *Ctl) PlanDir(ctx context.Context, dir string, prune bool) (Plan, error) {
+	local, err := LoadDir(dir)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	remote, err := c.ExportAll(ctx)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	return Diff(local, remote, prune), nil
+}
+
+// Apply выполняет план: новые и измененные баннеры загружаются одним
+// импортом с upsert, затем удаляются лишние.
+func (c *Ctl) Apply(ctx context.Context, plan Plan) error {
+	if len(plan.Create)+len(plan.Update) != 0 {
+		var buf bytes.Buffer
+
+		enc := json.NewEncoder(&buf)
+
+		for _, b := range append(slices.Clone(plan.Create), plan.Update...) {
+			// Идентификатор и даты назначает сервер.
+			b.ID, b.CreatedAt, b.UpdatedAt = 0, time.Time{}, time.Time{}
+			if err := enc.Encode(b); err != nil {
+				return fmt.Errorf("encode banner %q error: %w", b.ExternalKey, err)
+			}
+		}
+
+		upsert := true
+		mode := oapi.Atomic
+
+		report, err := c.Import(ctx, oapi.PostBannerImportParams{Mode: &mode, Upsert: &upsert}, &buf) //nolint:exhaustruct
+		if err != nil {
+			for _, e := range report.Errors {
+				fmt.Fprintf(c.out, "line %d: %s\n", e.Line, e.Error)
+			}
+
+			return err
+		}
+	}
+
+	for _, b := range plan.Delete {
+		if err := c.Delete(ctx, int(b.ID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PrintPlan выводит план в виде, привычном по terraform и kubectl diff.
+func (c *Ctl) PrintPlan(plan Plan) {
+	for _, b := range plan.Create {
+		fmt.Fprintf(c.out, "+ %s (feature %d, tags %s)\n", b.ExternalKey, b.FeatureID, joinInts(b.Tags))
+	}
+
+	for _, b := range plan.Update {
+		fmt.Fprintf(c.out, "~ %s (banner %d)\n", b.ExternalKey, b.ID)
+	}
+
+	for _, b := range plan.Delete {
+		fmt.Fprintf(c.out, "- %s (banner %d)\n", b.ExternalKey, b.ID)
+	}
+
+	fmt.Fprintf(c.out, "%d to create, %d to update, %d to delete, %d unchanged\n",
+		len(plan.Create), len(plan.Update), len(plan.Delete), plan.Unchanged)
+}
@@ -0,0 +1,72 @@
+package bannerctl_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Leopold1975/banners_control/internal/bannerctl"
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDirAndDiff(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "promo.yaml"), []byte(`
+external_key: promo
+feature_id: 1
+tag_ids: [2, 1]
+is_active: true
+content:
+  title: Promo
+  price: 100
+---
+external_key: new
+feature_id: 2
+tag_ids: [3]
+content:
+  title: New
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sale.json"),
+		[]byte(`[{"external_key": "sale", "feature_id": 3, "tag_ids": [4], "content": {"title": "Sale -50%"}}]`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a banner"), 0o600))
+
+	local, err := bannerctl.LoadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, local, 3)
+
+	remote := []models.Banner{
+		{ID: 1, ExternalKey: "promo", FeatureID: 1, Tags: []int{1, 2}, Active: true,
+			Content: map[string]interface{}{"title": "Promo", "price": float64(100)}},
+		{ID: 2, ExternalKey: "sale", FeatureID: 3, Tags: []int{4},
+			Content: map[string]interface{}{"title": "Sale -30%"}},
+		{ID: 3, ExternalKey: "old", FeatureID: 4, Tags: []int{5}},
+		{ID: 4, FeatureID: 5, Tags: []int{6}},
+	}
+
+	plan := bannerctl.Diff(local, remote, false)
+	require.Equal(t, 1, plan.Unchanged)
+	require.Len(t, plan.Create, 1)
+	require.Equal(t, "new", plan.Create[0].ExternalKey)
+	require.Len(t, plan.Update, 1)
+	require.Equal(t, int64(2), plan.Update[0].ID)
+	require.Empty(t, plan.Delete)
+
+	// Баннеры без external_key не удаляются даже с prune.
+	plan = bannerctl.Diff(local, remote, true)
+	require.Len(t, plan.Delete, 1)
+	require.Equal(t, int64(3), plan.Delete[0].ID)
+}
+
+func TestLoadDirDuplicateKey(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"a.json", "b.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name),
+			[]byte(`{"external_key": "same", "feature_id": 1, "tag_ids": [1], "content": {}}`), 0o600))
+	}
+
+	_, err := bannerctl.LoadDir(dir)
+	require.ErrorIs(t, err, bannerctl.ErrInvalidDir)
+}
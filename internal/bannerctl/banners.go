@@ -0,0 +1,396 @@
+package bannerctl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"gopkg.in/yaml.v3"
+)
+
+const pageSize = 100
+
+var (
+	ErrNotFound      = errors.New("banner not found")
+	ErrExternalKey   = errors.New("banners with external_key are created by apply or import")
+	ErrUnknownFormat = errors.New("unknown output format")
+)
+
+// Login получает токен по имени и паролю и сохраняет его для следующих команд.
+func (c *Ctl) Login(ctx context.Context, username, password string) error {
+	resp, err := c.client.PostAuth(ctx, oapi.PostAuthJSONRequestBody{
+		Username: &username,
+		Password: &password,
+	})
+	if err != nil {
+		return fmt.Errorf("auth request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return fmt.Errorf("auth error: %w", err)
+	}
+
+	var body struct {
+		Token string `json:"token"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("decode token error: %w", err)
+	}
+
+	return c.saveToken(body.Token)
+}
+
+// List возвращает баннеры по фильтрам, проходя по страницам курсором.
+// limit = 0 означает все баннеры.
+func (c *Ctl) List(ctx context.Context, params oapi.GetBannerParams, limit int) ([]models.Banner, error) {
+	token, err := c.adminToken()
+	if err != nil {
+		return nil, err
+	}
+
+	params.Token = token
+
+	var banners []models.Banner
+
+	for {
+		size := pageSize
+		if limit != 0 && limit-len(banners) < size {
+			size = limit - len(banners)
+		}
+
+		params.Limit = &size
+
+		page, next, err := c.listPage(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		banners = append(banners, page...)
+
+		if next == "" || (limit != 0 && len(banners) >= limit) {
+			return banners, nil
+		}
+
+		params.Cursor = &next
+	}
+}
+
+func (c *Ctl) listPage(ctx context.Context, params *oapi.GetBannerParams) ([]models.Banner, string, error) {
+	resp, err := c.client.GetBanner(ctx, params)
+	if err != nil {
+		return nil, "", fmt.Errorf("list request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, "", fmt.Errorf("list error: %w", err)
+	}
+
+	var page []models.Banner
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, "", fmt.Errorf("decode banners error: %w", err)
+	}
+
+	return page, resp.Header.Get("X-Next-Cursor"), nil
+}
+
+func (c *Ctl) Get(ctx context.Context, id int) (models.Banner, error) {
+	ids := []int{id}
+
+	banners, err := c.List(ctx, oapi.GetBannerParams{BannerIds: &ids}, 1) //nolint:exhaustruct
+	if err != nil {
+		return models.Banner{}, err
+	}
+
+	if len(banners) == 0 {
+		return models.Banner{}, fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+
+	return banners[0], nil
+}
+
+func (c *Ctl) Create(ctx context.Context, b models.Banner) (int, error) {
+	token, err := c.adminToken()
+	if err != nil {
+		return 0, err
+	}
+
+	if b.ExternalKey != "" {
+		return 0, ErrExternalKey
+	}
+
+	resp, err := c.client.PostBanner(ctx, &oapi.PostBannerParams{Token: token}, oapi.PostBannerJSONRequestBody{
+		Content:   &b.Content,
+		FeatureId: &b.FeatureID,
+		IsActive:  &b.Active,
+		TagIds:    &b.Tags,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("create request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusCreated); err != nil {
+		return 0, fmt.Errorf("create error: %w", err)
+	}
+
+	var body struct {
+		BannerID int `json:"banner_id"` //nolint:tagliatelle
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, fmt.Errorf("decode banner id error: %w", err)
+	}
+
+	return body.BannerID, nil
+}
+
+func (c *Ctl) Update(ctx context.Context, id int, b models.Banner) error {
+	token, err := c.adminToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.PatchBannerId(ctx, id, &oapi.PatchBannerIdParams{Token: token}, oapi.PatchBannerIdJSONRequestBody{
+		Content:   &b.Content,
+		FeatureId: &b.FeatureID,
+		IsActive:  &b.Active,
+		TagIds:    &b.Tags,
+	})
+	if err != nil {
+		return fmt.Errorf("update request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return fmt.Errorf("update banner %d error: %w", id, err)
+	}
+
+	return nil
+}
+
+func (c *Ctl) Delete(ctx context.Context, id int) error {
+	token, err := c.adminToken()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.DeleteBannerId(ctx, id, &oapi.DeleteBannerIdParams{Token: token})
+	if err != nil {
+		return fmt.Errorf("delete request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusNoContent, http.StatusOK); err != nil {
+		return fmt.Errorf("delete banner %d error: %w", id, err)
+	}
+
+	return nil
+}
+
+// Export пишет в w выгрузку баннеров в формате NDJSON.
+func (c *Ctl) Export(ctx context.Context, params oapi.GetBannerExportParams, w io.Writer) error {
+	token, err := c.adminToken()
+	if err != nil {
+		return err
+	}
+
+	params.Token = token
+
+	resp, err := c.client.GetBannerExport(ctx, &params)
+	if err != nil {
+		return fmt.Errorf("export request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return fmt.Errorf("export error: %w", err)
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("export error: %w", err)
+	}
+
+	return nil
+}
+
+// ExportAll возвращает все баннеры одной выгрузкой.
+func (c *Ctl) ExportAll(ctx context.Context) ([]models.Banner, error) {
+	var buf bytes.Buffer
+
+	if err := c.Export(ctx, oapi.GetBannerExportParams{}, &buf); err != nil { //nolint:exhaustruct
+		return nil, err
+	}
+
+	var banners []models.Banner
+
+	sc := bufio.NewScanner(&buf)
+	sc.Buffer(make([]byte, 0, 64<<10), 1<<20) //nolint:gomnd
+
+	for sc.Scan() {
+		var b models.Banner
+		if err := json.Unmarshal(sc.Bytes(), &b); err != nil {
+			return nil, fmt.Errorf("decode export error: %w", err)
+		}
+
+		banners = append(banners, b)
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read export error: %w", err)
+	}
+
+	return banners, nil
+}
+
+type ImportReport struct {
+	Total   int  `json:"total"`
+	Created int  `json:"created"`
+	Updated int  `json:"updated"`
+	Failed  int  `json:"failed"`
+	DryRun  bool `json:"dry_run"` //nolint:tagliatelle
+	Errors  []struct {
+		Line  int    `json:"line"`
+		Error string `json:"error"`
+	} `json:"errors"`
+}
+
+// Import загружает NDJSON из r. Если сервер отверг импорт, возвращается
+// отчет с ошибками строк вместе с ошибкой.
+func (c *Ctl) Import(ctx context.Context, params oapi.PostBannerImportParams, r io.Reader) (ImportReport, error) {
+	var report ImportReport
+
+	token, err := c.adminToken()
+	if err != nil {
+		return report, err
+	}
+
+	params.Token = token
+
+	resp, err := c.client.PostBannerImportWithBody(ctx, &params, "application/x-ndjson", r)
+	if err != nil {
+		return report, fmt.Errorf("import request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+			return report, fmt.Errorf("decode import report error: %w", err)
+		}
+
+		return report, fmt.Errorf("import rejected: %d of %d lines failed", report.Failed, report.Total) //nolint:goerr113
+	}
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return report, fmt.Errorf("import error: %w", err)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return report, fmt.Errorf("decode import report error: %w", err)
+	}
+
+	return report, nil
+}
+
+type User struct {
+	Username  string
+	Password  string
+	Role      string
+	FeatureID int
+	Tags      []int
+}
+
+// CreateUser создает пользователя и возвращает его токен. Для роли admin
+// нужен токен админа.
+func (c *Ctl) CreateUser(ctx context.Context, u User) (string, error) {
+	params := &oapi.PostUserParams{Token: nil}
+	if c.token != "" {
+		params.Token = &c.token
+	}
+
+	body := oapi.PostUserJSONRequestBody{
+		Username:  &u.Username,
+		Password:  &u.Password,
+		Role:      &u.Role,
+		FeatureId: &u.FeatureID,
+		TagIds:    &u.Tags,
+	}
+
+	resp, err := c.client.PostUser(ctx, params, body)
+	if err != nil {
+		return "", fmt.Errorf("create user request error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK, http.StatusCreated); err != nil {
+		return "", fmt.Errorf("create user error: %w", err)
+	}
+
+	var res struct {
+		Token string `json:"token"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return "", fmt.Errorf("decode token error: %w", err)
+	}
+
+	return res.Token, nil
+}
+
+// PrintBanners выводит баннеры таблицей (table), в JSON или YAML.
+func (c *Ctl) PrintBanners(banners []models.Banner, format string) error {
+	switch format {
+	case "", "table":
+		tw := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0) //nolint:gomnd
+		fmt.Fprintln(tw, "ID\tFEATURE\tTAGS\tACTIVE\tKEY\tUPDATED")
+
+		for _, b := range banners {
+			fmt.Fprintf(tw, "%d\t%d\t%s\t%t\t%s\t%s\n",
+				b.ID, b.FeatureID, joinInts(b.Tags), b.Active, b.ExternalKey, b.UpdatedAt.Format(time.RFC3339))
+		}
+
+		return tw.Flush() //nolint:wrapcheck
+	case "json":
+		enc := json.NewEncoder(c.out)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(banners) //nolint:wrapcheck
+	case "yaml":
+		// Через JSON, чтобы ключи совпадали с форматом файлов баннеров.
+		raw, err := json.Marshal(banners)
+		if err != nil {
+			return fmt.Errorf("marshal error: %w", err)
+		}
+
+		var doc interface{}
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return fmt.Errorf("unmarshal error: %w", err)
+		}
+
+		return yaml.NewEncoder(c.out).Encode(doc) //nolint:wrapcheck
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+func joinInts(ints []int) string {
+	s := make([]string, 0, len(ints))
+	for _, i := range ints {
+		s = append(s, strconv.Itoa(i))
+	}
+
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,583 @@
+package bannerctl
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+)
+
+const defaultServer = "http://localhost:5555/v1"
+
+var ErrUsage = errors.New("usage error")
+
+const usage = `Usage: bannerctl [-server URL] [-token TOKEN] <command> [flags]
+
+Commands:
+  login     -username NAME [-password PASS]   получить и сохранить токен
+  list      [filters] [-limit N] [-o table|json|yaml]
+  get       ID [-o table|json|yaml]
+  create    -f FILE                           создать баннеры из YAML/JSON
+  update    ID -f FILE                        заменить баннер содержимым файла
+  delete    ID...
+  export    [filters] [-f FILE]               выгрузить баннеры в NDJSON
+  import    -f FILE [-mode atomic|best_effort] [-upsert] [-dry-run]
+  user      create -username NAME -password PASS -feature ID -tags 1,2 [-role user|admin]
+  diff      -dir DIR [-prune]                 сравнить каталог баннеров с сервером
+  apply     -dir DIR [-prune] [-dry-run]      привести сервер к каталогу баннеров
+
+Адрес сервера и токен также берутся из BANNERCTL_SERVER и BANNERCTL_TOKEN,
+пароль для login - из BANNERCTL_PASSWORD.
+`
+
+// Run разбирает аргументы командной строки и выполняет команду.
+func Run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error { //nolint:cyclop
+	fs := flag.NewFlagSet("bannerctl", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	fs.Usage = func() { fmt.Fprint(stdout, usage) }
+
+	server := fs.String("server", envOr("BANNERCTL_SERVER", defaultServer), "server base URL")
+	token := fs.String("token", os.Getenv("BANNERCTL_TOKEN"), "admin token")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+
+		return ErrUsage
+	}
+
+	c, err := New(*server, *token, stdout)
+	if err != nil {
+		return err
+	}
+
+	cmd, args := fs.Arg(0), fs.Args()[1:]
+
+	switch cmd {
+	case "login":
+		return c.runLogin(ctx, args, stdin)
+	case "list":
+		return c.runList(ctx, args)
+	case "get":
+		return c.runGet(ctx, args)
+	case "create":
+		return c.runCreate(ctx, args)
+	case "update":
+		return c.runUpdate(ctx, args)
+	case "delete":
+		return c.runDelete(ctx, args)
+	case "export":
+		return c.runExport(ctx, args)
+	case "import":
+		return c.runImport(ctx, args)
+	case "user":
+		return c.runUser(ctx, args)
+	case "diff":
+		return c.runApply(ctx, args, true)
+	case "apply":
+		return c.runApply(ctx, args, false)
+	default:
+		fs.Usage()
+
+		return fmt.Errorf("%w: unknown command %q", ErrUsage, cmd)
+	}
+}
+
+func (c *Ctl) runLogin(ctx context.Context, args []string, stdin io.Reader) error {
+	fs := newFlagSet("login")
+	username := fs.String("username", "", "user name")
+	password := fs.String("password", os.Getenv("BANNERCTL_PASSWORD"), "password, read from stdin if empty")
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	if *username == "" {
+		return fmt.Errorf("%w: -username is required", ErrUsage)
+	}
+
+	if *password == "" {
+		fmt.Fprint(c.out, "Password: ")
+
+		line, err := bufio.NewReader(stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("read password error: %w", err)
+		}
+
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	if err := c.Login(ctx, *username, *password); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "logged in to %s\n", c.server)
+
+	return nil
+}
+
+// filterFlags - общие фильтры команд list и export.
+type filterFlags struct {
+	feature, tag      *int
+	tags              *string
+	tagMatch, active  *string
+	query, contentPth *string
+	sort, order       *string
+}
+
+func addFilterFlags(fs *flag.FlagSet) filterFlags {
+	return filterFlags{
+		feature:    fs.Int("feature", 0, "feature id"),
+		tag:        fs.Int("tag", 0, "tag id"),
+		tags:       fs.String("tags", "", "comma separated tag ids"),
+		tagMatch:   fs.String("tag-match", "", "any or all"),
+		active:     fs.String("active", "", "true or false"),
+		query:      fs.String("q", "", "full-text search over content"),
+		contentPth: fs.String("content-path", "", "JSONPath predicate over content"),
+		sort:       fs.String("sort", "", "id, created_at, updated_at or feature_id"),
+		order:      fs.String("order", "", "asc or desc"),
+	}
+}
+
+func (f filterFlags) params() (oapi.GetBannerParams, error) {
+	var p oapi.GetBannerParams
+
+	if *f.feature != 0 {
+		p.FeatureId = f.feature
+	}
+
+	if *f.tag != 0 {
+		p.TagId = f.tag
+	}
+
+	if *f.tags != "" {
+		tags, err := parseInts(*f.tags)
+		if err != nil {
+			return p, err
+		}
+
+		p.TagIds = &tags
+	}
+
+	if *f.tagMatch != "" {
+		m := oapi.GetBannerParamsTagMatch(*f.tagMatch)
+		p.TagMatch = &m
+	}
+
+	if *f.active != "" {
+		active, err := strconv.ParseBool(*f.active)
+		if err != nil {
+			return p, fmt.Errorf("%w: -active: %w", ErrUsage, err)
+		}
+
+		p.IsActive = &active
+	}
+
+	if *f.query != "" {
+		p.Q = f.query
+	}
+
+	if *f.contentPth != "" {
+		p.ContentPath = f.contentPth
+	}
+
+	if *f.sort != "" {
+		s := oapi.GetBannerParamsSort(*f.sort)
+		p.Sort = &s
+	}
+
+	if *f.order != "" {
+		o := oapi.GetBannerParamsOrder(*f.order)
+		p.Order = &o
+	}
+
+	return p, nil
+}
+
+func (c *Ctl) runList(ctx context.Context, args []string) error {
+	fs := newFlagSet("list")
+	filters := addFilterFlags(fs)
+	limit := fs.Int("limit", 0, "maximum number of banners, 0 for all")
+	output := fs.String("o", "table", "output format: table, json or yaml")
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	params, err := filters.params()
+	if err != nil {
+		return err
+	}
+
+	banners, err := c.List(ctx, params, *limit)
+	if err != nil {
+		return err
+	}
+
+	return c.PrintBanners(banners, *output)
+}
+
+func (c *Ctl) runGet(ctx context.Context, args []string) error {
+	fs := newFlagSet("get")
+	output := fs.String("o", "yaml", "output format: table, json or yaml")
+
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(pos[0])
+	if err != nil {
+		return err
+	}
+
+	b, err := c.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return c.PrintBanners([]models.Banner{b}, *output)
+}
+
+func (c *Ctl) runCreate(ctx context.Context, args []string) error {
+	fs := newFlagSet("create")
+	file := fs.String("f", "", "YAML or JSON file with banners")
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return fmt.Errorf("%w: -f is required", ErrUsage)
+	}
+
+	banners, err := ReadBannerFile(*file)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range banners {
+		id, err := c.Create(ctx, b)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(c.out, "created banner %d\n", id)
+	}
+
+	return nil
+}
+
+func (c *Ctl) runUpdate(ctx context.Context, args []string) error {
+	fs := newFlagSet("update")
+	file := fs.String("f", "", "YAML or JSON file with the banner")
+
+	pos, err := parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	id, err := parseID(pos[0])
+	if err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return fmt.Errorf("%w: -f is required", ErrUsage)
+	}
+
+	banners, err := ReadBannerFile(*file)
+	if err != nil {
+		return err
+	}
+
+	if len(banners) != 1 {
+		return fmt.Errorf("%w: %s must contain exactly one banner, got %d", ErrUsage, *file, len(banners))
+	}
+
+	if err := c.Update(ctx, id, banners[0]); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "updated banner %d\n", id)
+
+	return nil
+}
+
+func (c *Ctl) runDelete(ctx context.Context, args []string) error {
+	fs := newFlagSet("delete")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("%w: banner id is required", ErrUsage)
+	}
+
+	for _, arg := range fs.Args() {
+		id, err := parseID(arg)
+		if err != nil {
+			return err
+		}
+
+		if err := c.Delete(ctx, id); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(c.out, "deleted banner %d\n", id)
+	}
+
+	return nil
+}
+
+func (c *Ctl) runExport(ctx context.Context, args []string) error {
+	fs := newFlagSet("export")
+	filters := addFilterFlags(fs)
+	file := fs.String("f", "", "output file, stdout if empty")
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	p, err := filters.params()
+	if err != nil {
+		return err
+	}
+
+	params := oapi.GetBannerExportParams{ //nolint:exhaustruct
+		FeatureId:   p.FeatureId,
+		TagId:       p.TagId,
+		TagIds:      p.TagIds,
+		IsActive:    p.IsActive,
+		Q:           p.Q,
+		ContentPath: p.ContentPath,
+	}
+
+	if p.TagMatch != nil {
+		m := oapi.GetBannerExportParamsTagMatch(*p.TagMatch)
+		params.TagMatch = &m
+	}
+
+	if p.Sort != nil {
+		s := oapi.GetBannerExportParamsSort(*p.Sort)
+		params.Sort = &s
+	}
+
+	if p.Order != nil {
+		o := oapi.GetBannerExportParamsOrder(*p.Order)
+		params.Order = &o
+	}
+
+	if *file == "" {
+		return c.Export(ctx, params, c.out)
+	}
+
+	f, err := os.Create(*file)
+	if err != nil {
+		return fmt.Errorf("create %s error: %w", *file, err)
+	}
+
+	if err := c.Export(ctx, params, f); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close %s error: %w", *file, err)
+	}
+
+	return nil
+}
+
+func (c *Ctl) runImport(ctx context.Context, args []string) error {
+	fs := newFlagSet("import")
+	file := fs.String("f", "", "NDJSON file")
+	mode := fs.String("mode", "atomic", "atomic or best_effort")
+	upsert := fs.Bool("upsert", false, "update banners with the same external_key")
+	dryRun := fs.Bool("dry-run", false, "validate without saving")
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return fmt.Errorf("%w: -f is required", ErrUsage)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return fmt.Errorf("open %s error: %w", *file, err)
+	}
+	defer f.Close()
+
+	m := oapi.PostBannerImportParamsMode(*mode)
+
+	report, err := c.Import(ctx, oapi.PostBannerImportParams{ //nolint:exhaustruct
+		Mode:   &m,
+		Upsert: upsert,
+		DryRun: dryRun,
+	}, f)
+
+	for _, e := range report.Errors {
+		fmt.Fprintf(c.out, "line %d: %s\n", e.Line, e.Error)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "%d lines: %d created, %d updated, %d failed", report.Total, report.Created, report.Updated, report.Failed)
+
+	if report.DryRun {
+		fmt.Fprint(c.out, " (dry run)")
+	}
+
+	fmt.Fprintln(c.out)
+
+	return nil
+}
+
+func (c *Ctl) runUser(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "create" {
+		return fmt.Errorf("%w: expected user create", ErrUsage)
+	}
+
+	fs := newFlagSet("user create")
+	u := User{} //nolint:exhaustruct
+	fs.StringVar(&u.Username, "username", "", "user name")
+	fs.StringVar(&u.Password, "password", "", "password")
+	fs.StringVar(&u.Role, "role", "user", "user or admin")
+	feature := fs.Int("feature", 0, "feature id")
+	tags := fs.String("tags", "", "comma separated tag ids")
+
+	if _, err := parse(fs, args[1:], 0); err != nil {
+		return err
+	}
+
+	if u.Username == "" || u.Password == "" || *feature == 0 || *tags == "" {
+		return fmt.Errorf("%w: -username, -password, -feature and -tags are required", ErrUsage)
+	}
+
+	u.FeatureID = *feature
+
+	var err error
+
+	if u.Tags, err = parseInts(*tags); err != nil {
+		return err
+	}
+
+	token, err := c.CreateUser(ctx, u)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.out, token)
+
+	return nil
+}
+
+func (c *Ctl) runApply(ctx context.Context, args []string, diffOnly bool) error {
+	fs := newFlagSet("apply")
+	dir := fs.String("dir", "", "directory with banner files")
+	prune := fs.Bool("prune", false, "delete server banners with external_key missing locally")
+	dryRun := fs.Bool("dry-run", false, "print the plan without applying it")
+
+	if _, err := parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	if *dir == "" {
+		return fmt.Errorf("%w: -dir is required", ErrUsage)
+	}
+
+	plan, err := c.PlanDir(ctx, *dir, *prune)
+	if err != nil {
+		return err
+	}
+
+	c.PrintPlan(plan)
+
+	if diffOnly || *dryRun || plan.Empty() {
+		return nil
+	}
+
+	if err := c.Apply(ctx, plan); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.out, "applied")
+
+	return nil
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+// parse разбирает флаги команды и возвращает ровно nargs позиционных
+// аргументов. Позиционные аргументы могут стоять перед флагами:
+// bannerctl get 5 -o json.
+func parse(fs *flag.FlagSet, args []string, nargs int) ([]string, error) {
+	var pos []string
+
+	for len(args) > 0 && !strings.HasPrefix(args[0], "-") && len(pos) < nargs {
+		pos, args = append(pos, args[0]), args[1:]
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+
+	pos = append(pos, fs.Args()...)
+	if len(pos) != nargs {
+		return nil, fmt.Errorf("%w: %s expects %d argument(s), got %d", ErrUsage, fs.Name(), nargs, len(pos))
+	}
+
+	return pos, nil
+}
+
+func parseID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: invalid banner id %q", ErrUsage, s)
+	}
+
+	return id, nil
+}
+
+func parseInts(s string) ([]int, error) {
+	parts := strings.Split(s, ",")
+	ints := make([]int, 0, len(parts))
+
+	for _, p := range parts {
+		i, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid number %q", ErrUsage, p)
+		}
+
+		ints = append(ints, i)
+	}
+
+	return ints, nil
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+
+	return def
+}
@@ -0,0 +1,135 @@
+// Package bannerctl реализует команды утилиты bannerctl поверх
+// сгенерированного клиента oapi.
+package bannerctl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
+)
+
+var ErrNoToken = errors.New("not logged in: run bannerctl login or set BANNERCTL_TOKEN")
+
+// Ctl выполняет команды от имени одного пользователя одного сервера.
+type Ctl struct {
+	client *oapi.Client
+	server string
+	token  string
+	// credentials - файл с токенами, полученными командой login, по адресам серверов.
+	credentials string
+	out         io.Writer
+}
+
+// New создает Ctl для сервера server. Если token пуст, используется токен,
+// сохраненный командой login.
+func New(server, token string, out io.Writer) (*Ctl, error) {
+	client, err := oapi.NewClient(server)
+	if err != nil {
+		return nil, fmt.Errorf("create client error: %w", err)
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("config dir error: %w", err)
+	}
+
+	c := &Ctl{
+		client:      client,
+		server:      server,
+		token:       token,
+		credentials: filepath.Join(dir, "bannerctl", "credentials.json"),
+		out:         out,
+	}
+
+	if c.token == "" {
+		tokens, err := c.loadTokens()
+		if err != nil {
+			return nil, err
+		}
+
+		c.token = tokens[server]
+	}
+
+	return c, nil
+}
+
+// adminToken возвращает токен для запросов, которым он обязателен.
+func (c *Ctl) adminToken() (*string, error) {
+	if c.token == "" {
+		return nil, ErrNoToken
+	}
+
+	return &c.token, nil
+}
+
+func (c *Ctl) loadTokens() (map[string]string, error) {
+	tokens := make(map[string]string)
+
+	data, err := os.ReadFile(c.credentials)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read credentials error: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("parse credentials %s error: %w", c.credentials, err)
+	}
+
+	return tokens, nil
+}
+
+// saveToken сохраняет токен сервера. Файл доступен только владельцу.
+func (c *Ctl) saveToken(token string) error {
+	tokens, err := c.loadTokens()
+	if err != nil {
+		return err
+	}
+
+	tokens[c.server] = token
+
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal credentials error: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.credentials), 0o700); err != nil { //nolint:gomnd
+		return fmt.Errorf("create config dir error: %w", err)
+	}
+
+	if err := os.WriteFile(c.credentials, data, 0o600); err != nil { //nolint:gomnd
+		return fmt.Errorf("write credentials error: %w", err)
+	}
+
+	c.token = token
+
+	return nil
+}
+
+// checkResponse возвращает ошибку с текстом из тела ответа, если код ответа
+// не входит в want.
+func checkResponse(resp *http.Response, want ...int) error {
+	if slices.Contains(want, resp.StatusCode) {
+		return nil
+	}
+
+	var e struct {
+		Error string `json:"error"`
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10)) //nolint:gomnd
+	if json.Unmarshal(body, &e) == nil && e.Error != "" {
+		return fmt.Errorf("%s: %s", resp.Status, e.Error) //nolint:goerr113
+	}
+
+	return fmt.Errorf("%s", resp.Status) //nolint:goerr113
+}
@@ -0,0 +1,122 @@
+package bannerctl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"gopkg.in/yaml.v3"
+)
+
+// ReadBannerFile читает баннеры из YAML или JSON файла. Файл содержит один
+// баннер, список баннеров или, для YAML, несколько документов.
+func ReadBannerFile(path string) ([]models.Banner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s error: %w", path, err)
+	}
+
+	banners, err := parseBanners(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s error: %w", path, err)
+	}
+
+	return banners, nil
+}
+
+// ReadBannerDir читает баннеры из всех файлов .yaml, .yml и .json каталога
+// и его подкаталогов.
+func ReadBannerDir(dir string) (map[string][]models.Banner, error) {
+	files := make(map[string][]models.Banner)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !isBannerFile(path) {
+			return nil
+		}
+
+		banners, err := ReadBannerFile(path)
+		if err != nil {
+			return err
+		}
+
+		files[path] = banners
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk %s error: %w", dir, err)
+	}
+
+	return files, nil
+}
+
+func isBannerFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseBanners разбирает документы YAML (JSON - его частный случай) и
+// переводит их в баннеры через JSON, чтобы действовали json-теги модели,
+// а числа в содержимом имели тот же тип, что и в ответах сервера.
+func parseBanners(data []byte) ([]models.Banner, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+
+	var banners []models.Banner
+
+	for {
+		var doc interface{}
+
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("decode error: %w", err)
+		}
+
+		if doc == nil {
+			continue
+		}
+
+		raw, err := json.Marshal(doc)
+		if err != nil {
+			return nil, fmt.Errorf("convert error: %w", err)
+		}
+
+		if _, ok := doc.([]interface{}); ok {
+			var list []models.Banner
+			if err := json.Unmarshal(raw, &list); err != nil {
+				return nil, fmt.Errorf("decode banners error: %w", err)
+			}
+
+			banners = append(banners, list...)
+
+			continue
+		}
+
+		var b models.Banner
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, fmt.Errorf("decode banner error: %w", err)
+		}
+
+		banners = append(banners, b)
+	}
+
+	return banners, nil
+}
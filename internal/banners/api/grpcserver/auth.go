@@ -0,0 +1,56 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/pb"
+	"github.com/Leopold1975/banners_control/internal/banners/api/server"
+	"github.com/Leopold1975/banners_control/internal/banners/services/authservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type authServer struct {
+	pb.UnimplementedAuthServiceServer
+
+	authService server.AuthService
+}
+
+func (s *authServer) Auth(ctx context.Context, req *pb.AuthRequest) (*pb.AuthResponse, error) {
+	if req.GetUsername() == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "not enought parameters to auth user")
+	}
+
+	token, err := s.authService.Login(ctx, req.GetUsername(), req.GetPassword())
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "login error: %s", err.Error())
+	}
+
+	return &pb.AuthResponse{Token: token}, nil
+}
+
+// CreateUser создает пользователя. Для роли admin в метаданных нужен токен админа.
+func (s *authServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	if req.GetUsername() == "" || req.GetPassword() == "" || req.GetRole() == "" {
+		return nil, status.Error(codes.InvalidArgument, "not enought parameters to call create user")
+	}
+
+	token, err := s.authService.CreateUser(ctx, authservice.CreateUserRequest{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+		Role:     req.GetRole(),
+		Tags:     toInts(req.GetTagIds()),
+		Feature:  int(req.GetFeatureId()),
+		Token:    metadataValue(ctx, tokenKey),
+	})
+	if err != nil {
+		if errors.Is(err, authservice.ErrNotAllowed) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		return nil, status.Errorf(codes.Unauthenticated, "create user error: %s", err.Error())
+	}
+
+	return &pb.CreateUserResponse{Token: token}, nil
+}
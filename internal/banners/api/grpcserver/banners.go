@@ -0,0 +1,318 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/pb"
+	"github.com/Leopold1975/banners_control/internal/banners/api/server"
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+	"github.com/Leopold1975/banners_control/internal/banners/services/bannerservice"
+	"github.com/Leopold1975/banners_control/internal/banners/services/changebus"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatchSize ограничивает число пар фича-тэг в одном пакетном запросе, как в REST API.
+const maxBatchSize = 100
+
+type bannersServer struct {
+	pb.UnimplementedBannersServiceServer
+
+	bannerService server.BannerService
+	authService   server.AuthService
+	changes       Changes
+	statsService  server.StatsService
+	done          <-chan struct{}
+}
+
+// auth проверяет токен из метаданных и сообщает, принадлежит ли он админу.
+func (s *bannersServer) auth(ctx context.Context) (bool, error) {
+	token := metadataValue(ctx, tokenKey)
+	if token == "" {
+		return false, status.Error(codes.Unauthenticated, "token required")
+	}
+
+	isAdmin, err := s.authService.Auth(token)
+	if err != nil {
+		return false, status.Errorf(codes.Unauthenticated, "authorization error: %s", err.Error())
+	}
+
+	return isAdmin, nil
+}
+
+// impression учитывает показ баннера, если статистика включена.
+func (s *bannersServer) impression(bannerID int64, tagID int) {
+	if s.statsService != nil {
+		s.statsService.Impression(bannerID, tagID)
+	}
+}
+
+func (s *bannersServer) admin(ctx context.Context) error {
+	isAdmin, err := s.auth(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !isAdmin {
+		return status.Error(codes.PermissionDenied, "admin token required")
+	}
+
+	return nil
+}
+
+func (s *bannersServer) GetUserBanner(ctx context.Context,
+	req *pb.GetUserBannerRequest,
+) (*pb.GetUserBannerResponse, error) {
+	isAdmin, err := s.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.bannerService.GetBanner(ctx, bannerservice.GetBannerRequest{ //nolint:exhaustruct
+		FeatureID:       int(req.GetFeatureId()),
+		Tags:            []int{int(req.GetTagId())},
+		IsAdmin:         isAdmin,
+		UseLastRevision: req.GetUseLastRevision(),
+	})
+	if err != nil {
+		return nil, statusError("get banner error", err)
+	}
+
+	if len(resp.Banners) == 0 {
+		return nil, status.Error(codes.NotFound, bannerservice.ErrNotFound.Error())
+	}
+
+	shown := resp.Banners[rand.Intn(len(resp.Banners))] //nolint:gosec
+
+	b, err := toBanner(shown)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encode error: %s", err.Error())
+	}
+
+	s.impression(shown.ID, int(req.GetTagId()))
+
+	return &pb.GetUserBannerResponse{Content: b.GetContent(), Stale: resp.Stale}, nil
+}
+
+func (s *bannersServer) GetUserBannerBatch(ctx context.Context,
+	req *pb.GetUserBannerBatchRequest,
+) (*pb.GetUserBannerBatchResponse, error) {
+	isAdmin, err := s.auth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.GetItems()) == 0 || len(req.GetItems()) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "expected from 1 to %d items", maxBatchSize)
+	}
+
+	breq := bannerservice.GetUserBannersRequest{
+		Pairs:           make([]repo.FeatureTag, 0, len(req.GetItems())),
+		IsAdmin:         isAdmin,
+		UseLastRevision: req.GetUseLastRevision(),
+	}
+
+	for _, item := range req.GetItems() {
+		breq.Pairs = append(breq.Pairs, repo.FeatureTag{FeatureID: int(item.GetFeatureId()), TagID: int(item.GetTagId())})
+	}
+
+	resp, err := s.bannerService.GetUserBanners(ctx, breq)
+	if err != nil {
+		return nil, statusError("get banners error", err)
+	}
+
+	batch := &pb.GetUserBannerBatchResponse{Banners: make([]*pb.BatchBanner, 0, len(breq.Pairs)), Stale: resp.Stale}
+
+	for _, p := range breq.Pairs {
+		bb := &pb.BatchBanner{FeatureId: int32(p.FeatureID), TagId: int32(p.TagID)} //nolint:exhaustruct,gosec
+
+		if banners := resp.Banners[p]; len(banners) != 0 {
+			shown := banners[rand.Intn(len(banners))] //nolint:gosec
+
+			b, err := toBanner(shown)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "encode error: %s", err.Error())
+			}
+
+			bb.Content = b.GetContent()
+
+			s.impression(shown.ID, p.TagID)
+		} else {
+			bb.Error = bannerservice.ErrNotFound.Error()
+		}
+
+		batch.Banners = append(batch.Banners, bb)
+	}
+
+	return batch, nil
+}
+
+func (s *bannersServer) ListBanners(ctx context.Context, req *pb.ListBannersRequest) (*pb.ListBannersResponse, error) {
+	if err := s.admin(ctx); err != nil {
+		return nil, err
+	}
+
+	breq, err := bannersRequest(req.GetFilter(), req.GetSort(), req.GetDesc())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.GetCursor() != "" && req.GetOffset() != 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset and cursor are mutually exclusive")
+	}
+
+	breq.Limit = int(req.GetLimit())
+	breq.Offset = int(req.GetOffset())
+	breq.Cursor = req.GetCursor()
+	breq.WithTotal = req.GetWithTotal()
+
+	resp, err := s.bannerService.GetBanner(ctx, breq)
+	if err != nil {
+		return nil, statusError("get banner error", err)
+	}
+
+	list := &pb.ListBannersResponse{
+		Banners:    make([]*pb.Banner, 0, len(resp.Banners)),
+		NextCursor: resp.NextCursor,
+		Total:      int32(resp.Total), //nolint:gosec
+	}
+
+	for _, b := range resp.Banners {
+		pbb, err := toBanner(b)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "encode error: %s", err.Error())
+		}
+
+		list.Banners = append(list.Banners, pbb)
+	}
+
+	return list, nil
+}
+
+func (s *bannersServer) ExportBanners(req *pb.ExportBannersRequest, stream pb.BannersService_ExportBannersServer) error {
+	ctx := stream.Context()
+
+	if err := s.admin(ctx); err != nil {
+		return err
+	}
+
+	breq, err := bannersRequest(req.GetFilter(), req.GetSort(), req.GetDesc())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.bannerService.ExportBanners(ctx, breq, func(b models.Banner) error {
+		pbb, err := toBanner(b)
+		if err != nil {
+			return err
+		}
+
+		return stream.Send(pbb) //nolint:wrapcheck
+	})
+	if err != nil {
+		return statusError("export banners error", err)
+	}
+
+	return nil
+}
+
+func (s *bannersServer) CreateBanner(ctx context.Context,
+	req *pb.CreateBannerRequest,
+) (*pb.CreateBannerResponse, error) {
+	if err := s.admin(ctx); err != nil {
+		return nil, err
+	}
+
+	id, err := s.bannerService.CreateBanner(ctx, models.Banner{ //nolint:exhaustruct
+		FeatureID: int(req.GetFeatureId()),
+		Tags:      toInts(req.GetTagIds()),
+		Active:    req.GetIsActive(),
+		Content:   req.GetContent().AsMap(),
+	})
+	if err != nil {
+		return nil, statusError("create banner error", err)
+	}
+
+	return &pb.CreateBannerResponse{BannerId: int64(id)}, nil
+}
+
+func (s *bannersServer) UpdateBanner(ctx context.Context,
+	req *pb.UpdateBannerRequest,
+) (*pb.UpdateBannerResponse, error) {
+	if err := s.admin(ctx); err != nil {
+		return nil, err
+	}
+
+	err := s.bannerService.UpdateBanner(ctx, models.Banner{ //nolint:exhaustruct
+		ID:        req.GetBannerId(),
+		FeatureID: int(req.GetFeatureId()),
+		Tags:      toInts(req.GetTagIds()),
+		Active:    req.GetIsActive(),
+		Content:   req.GetContent().AsMap(),
+	})
+	if err != nil {
+		return nil, statusError("update banner error", err)
+	}
+
+	return &pb.UpdateBannerResponse{}, nil
+}
+
+func (s *bannersServer) DeleteBanner(ctx context.Context,
+	req *pb.DeleteBannerRequest,
+) (*pb.DeleteBannerResponse, error) {
+	if err := s.admin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.bannerService.DeleteBanner(ctx, int(req.GetBannerId())); err != nil {
+		return nil, statusError("delete banner error", err)
+	}
+
+	return &pb.DeleteBannerResponse{}, nil
+}
+
+// WatchBanners передает изменения баннеров, пока клиент не отключится.
+// Клиент, не успевающий читать события, отключается с RESOURCE_EXHAUSTED
+// и должен перечитать баннеры через ListBanners.
+func (s *bannersServer) WatchBanners(req *pb.WatchBannersRequest, stream pb.BannersService_WatchBannersServer) error {
+	ctx := stream.Context()
+
+	if err := s.admin(ctx); err != nil {
+		return err
+	}
+
+	sub := s.changes.Subscribe(changebus.Filter{FeatureID: int(req.GetFeatureId()), TagID: int(req.GetTagId())})
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.done:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case e, ok := <-sub.C():
+			if !ok {
+				if errors.Is(sub.Err(), changebus.ErrSlowSubscriber) {
+					return status.Error(codes.ResourceExhausted, sub.Err().Error())
+				}
+
+				return status.Error(codes.Unavailable, "subscription closed")
+			}
+
+			pbe, err := toEvent(e)
+			if err != nil {
+				logger.FromContext(ctx).Errorf("encode banner event error: %s", err.Error())
+
+				continue
+			}
+
+			if err := stream.Send(pbe); err != nil {
+				return err //nolint:wrapcheck
+			}
+		}
+	}
+}
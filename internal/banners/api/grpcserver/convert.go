@@ -0,0 +1,197 @@
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/pb"
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+	"github.com/Leopold1975/banners_control/internal/banners/services/bannerservice"
+	"github.com/Leopold1975/banners_control/internal/banners/services/changebus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toBanner(b models.Banner) (*pb.Banner, error) {
+	content, err := structpb.NewStruct(b.Content)
+	if err != nil {
+		return nil, fmt.Errorf("banner %d content error: %w", b.ID, err)
+	}
+
+	return &pb.Banner{
+		BannerId:    b.ID,
+		FeatureId:   int32(b.FeatureID), //nolint:gosec
+		TagIds:      toInt32s(b.Tags),
+		IsActive:    b.Active,
+		Content:     content,
+		CreatedAt:   timestamp(b.CreatedAt),
+		UpdatedAt:   timestamp(b.UpdatedAt),
+		ExternalKey: b.ExternalKey,
+	}, nil
+}
+
+// timestamp оставляет неизвестное время пустым, например дату создания
+// в событии об изменении баннера.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+func toEvent(e changebus.Event) (*pb.BannerEvent, error) {
+	b, err := toBanner(e.Banner)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := pb.BannerEvent_TYPE_UNSPECIFIED
+
+	switch e.Type {
+	case changebus.Created:
+		typ = pb.BannerEvent_TYPE_CREATED
+	case changebus.Updated:
+		typ = pb.BannerEvent_TYPE_UPDATED
+	case changebus.Deleted:
+		typ = pb.BannerEvent_TYPE_DELETED
+	}
+
+	var prev *pb.Banner
+
+	if e.Previous != nil {
+		prev, err = toBanner(*e.Previous)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &pb.BannerEvent{
+		Seq:      e.Seq,
+		Type:     typ,
+		Banner:   b,
+		At:       timestamppb.New(e.At),
+		Previous: prev,
+	}, nil
+}
+
+func toInt32s(ints []int) []int32 {
+	res := make([]int32, 0, len(ints))
+	for _, i := range ints {
+		res = append(res, int32(i)) //nolint:gosec
+	}
+
+	return res
+}
+
+func toInts(ints []int32) []int {
+	res := make([]int, 0, len(ints))
+	for _, i := range ints {
+		res = append(res, int(i))
+	}
+
+	return res
+}
+
+// bannersRequest переводит фильтры в запрос к сервису так же, как REST API
+// переводит параметры GET /banner.
+func bannersRequest(f *pb.BannerFilter, sort pb.SortField, desc bool) (bannerservice.GetBannerRequest, error) {
+	var (
+		req bannerservice.GetBannerRequest
+		err error
+	)
+
+	req.FeatureID = -1
+	req.IsAdmin = true
+	req.Desc = desc
+
+	switch sort {
+	case pb.SortField_SORT_FIELD_UNSPECIFIED:
+	case pb.SortField_SORT_FIELD_ID:
+		req.Sort = repo.SortByID
+	case pb.SortField_SORT_FIELD_CREATED_AT:
+		req.Sort = repo.SortByCreatedAt
+	case pb.SortField_SORT_FIELD_UPDATED_AT:
+		req.Sort = repo.SortByUpdatedAt
+	case pb.SortField_SORT_FIELD_FEATURE_ID:
+		req.Sort = repo.SortByFeatureID
+	default:
+		return req, fmt.Errorf("%w: unknown sort %d", bannerservice.ErrInvalidFilter, sort)
+	}
+
+	if f == nil {
+		return req, nil
+	}
+
+	req.IDs = f.GetBannerIds()
+	req.FeatureIDs = toInts(f.GetFeatureIds())
+	req.Tags = toInts(f.GetTagIds())
+	req.Search = strings.TrimSpace(f.GetQ())
+	req.ContentPath = f.GetContentPath()
+
+	if len(req.FeatureIDs) == 1 {
+		req.FeatureID, req.FeatureIDs = req.FeatureIDs[0], nil
+	}
+
+	switch f.GetTagMatch() {
+	case pb.TagMatch_TAG_MATCH_UNSPECIFIED:
+	case pb.TagMatch_TAG_MATCH_ANY:
+		req.TagMatch = repo.TagMatchAny
+	case pb.TagMatch_TAG_MATCH_ALL:
+		req.TagMatch = repo.TagMatchAll
+	default:
+		return req, fmt.Errorf("%w: unknown tag_match %d", bannerservice.ErrInvalidFilter, f.GetTagMatch())
+	}
+
+	if f.IsActive != nil {
+		active := f.GetIsActive()
+		req.Active = &active
+	}
+
+	if f.GetCreatedFrom() != nil {
+		req.CreatedFrom = f.GetCreatedFrom().AsTime()
+	}
+
+	if f.GetCreatedTo() != nil {
+		req.CreatedTo = f.GetCreatedTo().AsTime()
+	}
+
+	if f.GetUpdatedFrom() != nil {
+		req.UpdatedFrom = f.GetUpdatedFrom().AsTime()
+	}
+
+	if f.GetUpdatedTo() != nil {
+		req.UpdatedTo = f.GetUpdatedTo().AsTime()
+	}
+
+	if len(f.GetContent()) != 0 {
+		req.ContentEq, err = bannerservice.ParseContentFilter(f.GetContent())
+		if err != nil {
+			return req, err
+		}
+	}
+
+	return req, nil
+}
+
+// statusError переводит ошибку сервиса в статус gRPC по тем же правилам,
+// по которым REST API выбирает код ответа.
+func statusError(msg string, err error) error {
+	code := codes.Internal
+
+	switch {
+	case errors.Is(err, bannerservice.ErrNotFound), errors.Is(err, repo.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, bannerservice.ErrUnavailable):
+		code = codes.Unavailable
+	case errors.Is(err, bannerservice.ErrInvalidFilter), errors.Is(err, bannerservice.ErrInvalidCursor):
+		code = codes.InvalidArgument
+	}
+
+	return status.Errorf(code, "%s: %s", msg, err.Error())
+}
@@ -0,0 +1,145 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/server"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	tokenKey     = "token"
+	requestIDKey = "x-request-id"
+	// maxRequestIDLength ограничивает длину идентификатора, принятого от клиента.
+	maxRequestIDLength = 128
+)
+
+// unaryInterceptor делает для gRPC то же, что цепочка middleware REST API:
+// назначает идентификатор запроса, начинает спан, кладет в контекст логгер
+// запроса и пишет запись о каждом вызове.
+func unaryInterceptor(lg logger.Logger, as server.AuthService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, finish := startCall(ctx, lg, as, info.FullMethod)
+
+		resp, err := handler(ctx, req)
+		finish(err)
+
+		return resp, err
+	}
+}
+
+func streamInterceptor(lg logger.Logger, as server.AuthService) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, finish := startCall(ss.Context(), lg, as, info.FullMethod)
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		finish(err)
+
+		return err
+	}
+}
+
+func startCall(ctx context.Context, lg logger.Logger, as server.AuthService,
+	method string,
+) (context.Context, func(error)) {
+	start := time.Now()
+
+	id := metadataValue(ctx, requestIDKey)
+	if !validRequestID(id) {
+		id = uuid.NewString()
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id)) //nolint:errcheck
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, method, trace.WithSpanKind(trace.SpanKindServer))
+
+	l := lg.With(
+		"request_id", id,
+		"route", method,
+		"user", principal(ctx, as),
+	)
+	ctx = logger.WithContext(ctx, l)
+
+	return ctx, func(err error) {
+		defer span.End()
+
+		code := status.Code(err)
+		fields := []interface{}{
+			"code", code.String(),
+			"latency", time.Since(start).String(),
+		}
+
+		if err != nil {
+			fields = append(fields, "error", status.Convert(err).Message())
+		}
+
+		switch code { //nolint:exhaustive
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+			span.SetStatus(otelcodes.Error, code.String())
+			l.Errorw("request", fields...)
+		default:
+			l.Infow("request", fields...)
+		}
+	}
+}
+
+// serverStream подменяет контекст потока контекстом с логгером и спаном.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context //nolint:containedctx
+}
+
+func (ss *serverStream) Context() context.Context {
+	return ss.ctx
+}
+
+func metadataValue(ctx context.Context, key string) string {
+	vals := metadata.ValueFromIncomingContext(ctx, key)
+	if len(vals) == 0 {
+		return ""
+	}
+
+	return vals[0]
+}
+
+// principal определяет, от чьего имени выполнен вызов, по токену из метаданных.
+func principal(ctx context.Context, as server.AuthService) string {
+	token := metadataValue(ctx, tokenKey)
+	if token == "" {
+		return "anonymous"
+	}
+
+	p, err := as.Principal(token)
+	if err != nil {
+		return "invalid token"
+	}
+
+	return p
+}
+
+// validRequestID отбрасывает пустые, слишком длинные и содержащие
+// непечатные символы идентификаторы, чтобы они не портили логи.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := range len(id) {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
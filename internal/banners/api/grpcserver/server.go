@@ -0,0 +1,114 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/pb"
+	"github.com/Leopold1975/banners_control/internal/banners/api/server"
+	"github.com/Leopold1975/banners_control/internal/banners/services/changebus"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+)
+
+const tracerName = "github.com/Leopold1975/banners_control/internal/banners/api/grpcserver"
+
+// Changes выдает подписки на изменения баннеров для WatchBanners.
+type Changes interface {
+	Subscribe(changebus.Filter) *changebus.Subscription
+}
+
+// Server обслуживает gRPC API поверх тех же сервисов, что и REST API.
+type Server struct {
+	cfg    config.GRPC
+	serv   *grpc.Server
+	health *health.Server
+	// done закрывается при остановке, чтобы завершить бесконечные потоки
+	// WatchBanners: иначе GracefulStop ждал бы их до истечения таймаута.
+	done     chan struct{}
+	stopOnce *sync.Once
+}
+
+// ss равен nil, если статистика выключена: показы тогда не считаются.
+func New(cfg config.GRPC, bs server.BannerService, as server.AuthService, changes Changes,
+	ss server.StatsService, lg logger.Logger,
+) *Server {
+	s := &Server{
+		cfg:      cfg,
+		serv:     nil,
+		health:   health.NewServer(),
+		done:     make(chan struct{}),
+		stopOnce: new(sync.Once),
+	}
+
+	s.serv = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptor(lg, as)),
+		grpc.ChainStreamInterceptor(streamInterceptor(lg, as)),
+		grpc.KeepaliveParams(keepalive.ServerParameters{ //nolint:exhaustruct
+			MaxConnectionAge: cfg.MaxConnectionAge,
+		}),
+	)
+
+	pb.RegisterBannersServiceServer(s.serv, &bannersServer{
+		UnimplementedBannersServiceServer: pb.UnimplementedBannersServiceServer{},
+		bannerService:                     bs,
+		authService:                       as,
+		changes:                           changes,
+		statsService:                      ss,
+		done:                              s.done,
+	})
+	pb.RegisterAuthServiceServer(s.serv, &authServer{
+		UnimplementedAuthServiceServer: pb.UnimplementedAuthServiceServer{},
+		authService:                    as,
+	})
+	healthpb.RegisterHealthServer(s.serv, s.health)
+	reflection.Register(s.serv)
+
+	return s
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	lis, err := (&net.ListenConfig{}).Listen(ctx, "tcp", s.cfg.Addr) //nolint:exhaustruct
+	if err != nil {
+		return fmt.Errorf("listen error: %w", err)
+	}
+
+	if err := s.serv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("serve error: %w", err)
+	}
+
+	return nil
+}
+
+// Shutdown переводит проверку состояния в NOT_SERVING, закрывает потоки
+// изменений и ждет завершения остальных запросов до истечения ctx.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		s.health.Shutdown()
+		close(s.done)
+	})
+
+	stopped := make(chan struct{})
+
+	go func() {
+		s.serv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.serv.Stop()
+
+		return fmt.Errorf("graceful stop error: %w", ctx.Err())
+	}
+}
@@ -90,6 +90,14 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// GetAdminLogLevel request
+	GetAdminLogLevel(ctx context.Context, params *GetAdminLogLevelParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutAdminLogLevelWithBody request with any body
+	PutAdminLogLevelWithBody(ctx context.Context, params *PutAdminLogLevelParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutAdminLogLevel(ctx context.Context, params *PutAdminLogLevelParams, body PutAdminLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostAuthWithBody request with any body
 	PostAuthWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -103,6 +111,12 @@ type ClientInterface interface {
 
 	PostBanner(ctx context.Context, params *PostBannerParams, body PostBannerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetBannerExport request
+	GetBannerExport(ctx context.Context, params *GetBannerExportParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostBannerImportWithBody request with any body
+	PostBannerImportWithBody(ctx context.Context, params *PostBannerImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeleteBannerId request
 	DeleteBannerId(ctx context.Context, id int, params *DeleteBannerIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -111,9 +125,26 @@ type ClientInterface interface {
 
 	PatchBannerId(ctx context.Context, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetBannerIdStats request
+	GetBannerIdStats(ctx context.Context, id int, params *GetBannerIdStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetDocs request
 	GetDocs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// PostEventsClickWithBody request with any body
+	PostEventsClickWithBody(ctx context.Context, params *PostEventsClickParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostEventsClick(ctx context.Context, params *PostEventsClickParams, body PostEventsClickJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHealthz request
+	GetHealthz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLivez request
+	GetLivez(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReadyz request
+	GetReadyz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostUserWithBody request with any body
 	PostUserWithBody(ctx context.Context, params *PostUserParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -121,6 +152,64 @@ type ClientInterface interface {
 
 	// GetUserBanner request
 	GetUserBanner(ctx context.Context, params *GetUserBannerParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUserBannerBatchWithBody request with any body
+	PostUserBannerBatchWithBody(ctx context.Context, params *PostUserBannerBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostUserBannerBatch(ctx context.Context, params *PostUserBannerBatchParams, body PostUserBannerBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUserBannerStream request
+	GetUserBannerStream(ctx context.Context, params *GetUserBannerStreamParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhooks request
+	GetWebhooks(ctx context.Context, params *GetWebhooksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostWebhooksWithBody request with any body
+	PostWebhooksWithBody(ctx context.Context, params *PostWebhooksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostWebhooks(ctx context.Context, params *PostWebhooksParams, body PostWebhooksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteWebhooksId request
+	DeleteWebhooksId(ctx context.Context, id int, params *DeleteWebhooksIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhooksIdDeliveries request
+	GetWebhooksIdDeliveries(ctx context.Context, id int, params *GetWebhooksIdDeliveriesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetAdminLogLevel(ctx context.Context, params *GetAdminLogLevelParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAdminLogLevelRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAdminLogLevelWithBody(ctx context.Context, params *PutAdminLogLevelParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminLogLevelRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutAdminLogLevel(ctx context.Context, params *PutAdminLogLevelParams, body PutAdminLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutAdminLogLevelRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) PostAuthWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -183,6 +272,30 @@ func (c *Client) PostBanner(ctx context.Context, params *PostBannerParams, body
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetBannerExport(ctx context.Context, params *GetBannerExportParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBannerExportRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostBannerImportWithBody(ctx context.Context, params *PostBannerImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostBannerImportRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) DeleteBannerId(ctx context.Context, id int, params *DeleteBannerIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewDeleteBannerIdRequest(c.Server, id, params)
 	if err != nil {
@@ -219,6 +332,18 @@ func (c *Client) PatchBannerId(ctx context.Context, id int, params *PatchBannerI
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetBannerIdStats(ctx context.Context, id int, params *GetBannerIdStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBannerIdStatsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetDocs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetDocsRequest(c.Server)
 	if err != nil {
@@ -231,6 +356,66 @@ func (c *Client) GetDocs(ctx context.Context, reqEditors ...RequestEditorFn) (*h
 	return c.Client.Do(req)
 }
 
+func (c *Client) PostEventsClickWithBody(ctx context.Context, params *PostEventsClickParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEventsClickRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEventsClick(ctx context.Context, params *PostEventsClickParams, body PostEventsClickJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEventsClickRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHealthz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthzRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLivez(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLivezRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReadyz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReadyzRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) PostUserWithBody(ctx context.Context, params *PostUserParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewPostUserRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
@@ -267,48 +452,104 @@ func (c *Client) GetUserBanner(ctx context.Context, params *GetUserBannerParams,
 	return c.Client.Do(req)
 }
 
-// NewPostAuthRequest calls the generic PostAuth builder with application/json body
-func NewPostAuthRequest(server string, body PostAuthJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) PostUserBannerBatchWithBody(ctx context.Context, params *PostUserBannerBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUserBannerBatchRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPostAuthRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewPostAuthRequestWithBody generates requests for PostAuth with any type of body
-func NewPostAuthRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+func (c *Client) PostUserBannerBatch(ctx context.Context, params *PostUserBannerBatchParams, body PostUserBannerBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUserBannerBatchRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) GetUserBannerStream(ctx context.Context, params *GetUserBannerStreamParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUserBannerStreamRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	operationPath := fmt.Sprintf("/auth")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+func (c *Client) GetWebhooks(ctx context.Context, params *GetWebhooksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhooksRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) PostWebhooksWithBody(ctx context.Context, params *PostWebhooksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostWebhooksRequestWithBody(c.Server, params, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+func (c *Client) PostWebhooks(ctx context.Context, params *PostWebhooksParams, body PostWebhooksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostWebhooksRequest(c.Server, params, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req.Header.Add("Content-Type", contentType)
+func (c *Client) DeleteWebhooksId(ctx context.Context, id int, params *DeleteWebhooksIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteWebhooksIdRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	return req, nil
+func (c *Client) GetWebhooksIdDeliveries(ctx context.Context, id int, params *GetWebhooksIdDeliveriesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhooksIdDeliveriesRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetBannerRequest generates requests for GetBanner
-func NewGetBannerRequest(server string, params *GetBannerParams) (*http.Request, error) {
+// NewGetAdminLogLevelRequest generates requests for GetAdminLogLevel
+func NewGetAdminLogLevelRequest(server string, params *GetAdminLogLevelParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -316,7 +557,7 @@ func NewGetBannerRequest(server string, params *GetBannerParams) (*http.Request,
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/banner")
+	operationPath := fmt.Sprintf("/admin/log_level")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -326,81 +567,66 @@ func NewGetBannerRequest(server string, params *GetBannerParams) (*http.Request,
 		return nil, err
 	}
 
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
 	if params != nil {
-		queryValues := queryURL.Query()
 
-		if params.FeatureId != nil {
+		if params.Token != nil {
+			var headerParam0 string
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_id", runtime.ParamLocationQuery, *params.FeatureId); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
 				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
 			}
 
+			req.Header.Set("token", headerParam0)
 		}
 
-		if params.TagId != nil {
+	}
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_id", runtime.ParamLocationQuery, *params.TagId); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
+	return req, nil
+}
 
-		}
+// NewPutAdminLogLevelRequest calls the generic PutAdminLogLevel builder with application/json body
+func NewPutAdminLogLevelRequest(server string, params *PutAdminLogLevelParams, body PutAdminLogLevelJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutAdminLogLevelRequestWithBody(server, params, "application/json", bodyReader)
+}
 
-		if params.Limit != nil {
+// NewPutAdminLogLevelRequestWithBody generates requests for PutAdminLogLevel with any type of body
+func NewPutAdminLogLevelRequestWithBody(server string, params *PutAdminLogLevelParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		if params.Offset != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
 
-		}
+	operationPath := fmt.Sprintf("/admin/log_level")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-		queryURL.RawQuery = queryValues.Encode()
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	if params != nil {
 
 		if params.Token != nil {
@@ -419,19 +645,19 @@ func NewGetBannerRequest(server string, params *GetBannerParams) (*http.Request,
 	return req, nil
 }
 
-// NewPostBannerRequest calls the generic PostBanner builder with application/json body
-func NewPostBannerRequest(server string, params *PostBannerParams, body PostBannerJSONRequestBody) (*http.Request, error) {
+// NewPostAuthRequest calls the generic PostAuth builder with application/json body
+func NewPostAuthRequest(server string, body PostAuthJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostBannerRequestWithBody(server, params, "application/json", bodyReader)
+	return NewPostAuthRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPostBannerRequestWithBody generates requests for PostBanner with any type of body
-func NewPostBannerRequestWithBody(server string, params *PostBannerParams, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostAuthRequestWithBody generates requests for PostAuth with any type of body
+func NewPostAuthRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -439,7 +665,7 @@ func NewPostBannerRequestWithBody(server string, params *PostBannerParams, conte
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/banner")
+	operationPath := fmt.Sprintf("/auth")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -456,41 +682,19 @@ func NewPostBannerRequestWithBody(server string, params *PostBannerParams, conte
 
 	req.Header.Add("Content-Type", contentType)
 
-	if params != nil {
-
-		if params.Token != nil {
-			var headerParam0 string
-
-			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
-			if err != nil {
-				return nil, err
-			}
-
-			req.Header.Set("token", headerParam0)
-		}
-
-	}
-
 	return req, nil
 }
 
-// NewDeleteBannerIdRequest generates requests for DeleteBannerId
-func NewDeleteBannerIdRequest(server string, id int, params *DeleteBannerIdParams) (*http.Request, error) {
+// NewGetBannerRequest generates requests for GetBanner
+func NewGetBannerRequest(server string, params *GetBannerParams) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/banner/%s", pathParam0)
+	operationPath := fmt.Sprintf("/banner")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -500,131 +704,368 @@ func NewDeleteBannerIdRequest(server string, id int, params *DeleteBannerIdParam
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-
 	if params != nil {
+		queryValues := queryURL.Query()
 
-		if params.Token != nil {
-			var headerParam0 string
+		if params.FeatureId != nil {
 
-			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
-			if err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_id", runtime.ParamLocationQuery, *params.FeatureId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
 			}
 
-			req.Header.Set("token", headerParam0)
 		}
 
-	}
+		if params.TagId != nil {
 
-	return req, nil
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_id", runtime.ParamLocationQuery, *params.TagId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-// NewPatchBannerIdRequest calls the generic PatchBannerId builder with application/json body
-func NewPatchBannerIdRequest(server string, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewPatchBannerIdRequestWithBody(server, id, params, "application/json", bodyReader)
-}
+		}
 
-// NewPatchBannerIdRequestWithBody generates requests for PatchBannerId with any type of body
-func NewPatchBannerIdRequestWithBody(server string, id int, params *PatchBannerIdParams, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+		if params.BannerIds != nil {
 
-	var pathParam0 string
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "banner_ids", runtime.ParamLocationQuery, *params.BannerIds); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
-	if err != nil {
-		return nil, err
-	}
+		}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+		if params.FeatureIds != nil {
 
-	operationPath := fmt.Sprintf("/banner/%s", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_ids", runtime.ParamLocationQuery, *params.FeatureIds); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+		}
 
-	req, err := http.NewRequest("PATCH", queryURL.String(), body)
-	if err != nil {
-		return nil, err
-	}
+		if params.TagIds != nil {
 
-	req.Header.Add("Content-Type", contentType)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_ids", runtime.ParamLocationQuery, *params.TagIds); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	if params != nil {
+		}
 
-		if params.Token != nil {
-			var headerParam0 string
+		if params.TagMatch != nil {
 
-			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
-			if err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_match", runtime.ParamLocationQuery, *params.TagMatch); err != nil {
 				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
 			}
 
-			req.Header.Set("token", headerParam0)
 		}
 
-	}
+		if params.IsActive != nil {
 
-	return req, nil
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "is_active", runtime.ParamLocationQuery, *params.IsActive); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-// NewGetDocsRequest generates requests for GetDocs
-func NewGetDocsRequest(server string) (*http.Request, error) {
-	var err error
+		}
 
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
+		if params.CreatedFrom != nil {
 
-	operationPath := fmt.Sprintf("/docs")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_from", runtime.ParamLocationQuery, *params.CreatedFrom); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
+		}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+		if params.CreatedTo != nil {
 
-	return req, nil
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_to", runtime.ParamLocationQuery, *params.CreatedTo); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-// NewPostUserRequest calls the generic PostUser builder with application/json body
-func NewPostUserRequest(server string, params *PostUserParams, body PostUserJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
+		}
+
+		if params.UpdatedFrom != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "updated_from", runtime.ParamLocationQuery, *params.UpdatedFrom); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.UpdatedTo != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "updated_to", runtime.ParamLocationQuery, *params.UpdatedTo); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Content != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "content", runtime.ParamLocationQuery, *params.Content); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ContentPath != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "content_path", runtime.ParamLocationQuery, *params.ContentPath); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Order != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "order", runtime.ParamLocationQuery, *params.Order); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.WithTotal != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "with_total", runtime.ParamLocationQuery, *params.WithTotal); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostBannerRequest calls the generic PostBanner builder with application/json body
+func NewPostBannerRequest(server string, params *PostBannerParams, body PostBannerJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPostUserRequestWithBody(server, params, "application/json", bodyReader)
+	return NewPostBannerRequestWithBody(server, params, "application/json", bodyReader)
 }
 
-// NewPostUserRequestWithBody generates requests for PostUser with any type of body
-func NewPostUserRequestWithBody(server string, params *PostUserParams, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostBannerRequestWithBody generates requests for PostBanner with any type of body
+func NewPostBannerRequestWithBody(server string, params *PostBannerParams, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -632,7 +1073,7 @@ func NewPostUserRequestWithBody(server string, params *PostUserParams, contentTy
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/user")
+	operationPath := fmt.Sprintf("/banner")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -667,8 +1108,8 @@ func NewPostUserRequestWithBody(server string, params *PostUserParams, contentTy
 	return req, nil
 }
 
-// NewGetUserBannerRequest generates requests for GetUserBanner
-func NewGetUserBannerRequest(server string, params *GetUserBannerParams) (*http.Request, error) {
+// NewGetBannerExportRequest generates requests for GetBannerExport
+func NewGetBannerExportRequest(server string, params *GetBannerExportParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -676,7 +1117,7 @@ func NewGetUserBannerRequest(server string, params *GetUserBannerParams) (*http.
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/user_banner")
+	operationPath := fmt.Sprintf("/banner/export")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -689,33 +1130,25 @@ func NewGetUserBannerRequest(server string, params *GetUserBannerParams) (*http.
 	if params != nil {
 		queryValues := queryURL.Query()
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_id", runtime.ParamLocationQuery, params.TagId); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
-				}
-			}
-		}
+		if params.FeatureId != nil {
 
-		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_id", runtime.ParamLocationQuery, params.FeatureId); err != nil {
-			return nil, err
-		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-			return nil, err
-		} else {
-			for k, v := range parsed {
-				for _, v2 := range v {
-					queryValues.Add(k, v2)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_id", runtime.ParamLocationQuery, *params.FeatureId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
 				}
 			}
+
 		}
 
-		if params.UseLastRevision != nil {
+		if params.TagId != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "use_last_revision", runtime.ParamLocationQuery, *params.UseLastRevision); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_id", runtime.ParamLocationQuery, *params.TagId); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -729,475 +1162,2741 @@ func NewGetUserBannerRequest(server string, params *GetUserBannerParams) (*http.
 
 		}
 
-		queryURL.RawQuery = queryValues.Encode()
-	}
+		if params.BannerIds != nil {
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "banner_ids", runtime.ParamLocationQuery, *params.BannerIds); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	if params != nil {
+		}
 
-		if params.Token != nil {
-			var headerParam0 string
+		if params.FeatureIds != nil {
 
-			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
-			if err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_ids", runtime.ParamLocationQuery, *params.FeatureIds); err != nil {
 				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
 			}
 
-			req.Header.Set("token", headerParam0)
 		}
 
-	}
+		if params.TagIds != nil {
 
-	return req, nil
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_ids", runtime.ParamLocationQuery, *params.TagIds); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
 		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
+
+		if params.TagMatch != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_match", runtime.ParamLocationQuery, *params.TagMatch); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
 		}
-	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+		if params.IsActive != nil {
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return &ClientWithResponses{client}, nil
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "is_active", runtime.ParamLocationQuery, *params.IsActive); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
 		}
-		c.Server = newBaseURL.String()
-		return nil
-	}
-}
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// PostAuthWithBodyWithResponse request with any body
-	PostAuthWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthResponse, error)
+		if params.CreatedFrom != nil {
 
-	PostAuthWithResponse(ctx context.Context, body PostAuthJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthResponse, error)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_from", runtime.ParamLocationQuery, *params.CreatedFrom); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	// GetBannerWithResponse request
-	GetBannerWithResponse(ctx context.Context, params *GetBannerParams, reqEditors ...RequestEditorFn) (*GetBannerResponse, error)
+		}
 
-	// PostBannerWithBodyWithResponse request with any body
-	PostBannerWithBodyWithResponse(ctx context.Context, params *PostBannerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBannerResponse, error)
+		if params.CreatedTo != nil {
 
-	PostBannerWithResponse(ctx context.Context, params *PostBannerParams, body PostBannerJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBannerResponse, error)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_to", runtime.ParamLocationQuery, *params.CreatedTo); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	// DeleteBannerIdWithResponse request
-	DeleteBannerIdWithResponse(ctx context.Context, id int, params *DeleteBannerIdParams, reqEditors ...RequestEditorFn) (*DeleteBannerIdResponse, error)
+		}
 
-	// PatchBannerIdWithBodyWithResponse request with any body
-	PatchBannerIdWithBodyWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error)
+		if params.UpdatedFrom != nil {
 
-	PatchBannerIdWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "updated_from", runtime.ParamLocationQuery, *params.UpdatedFrom); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	// GetDocsWithResponse request
-	GetDocsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDocsResponse, error)
+		}
 
-	// PostUserWithBodyWithResponse request with any body
-	PostUserWithBodyWithResponse(ctx context.Context, params *PostUserParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUserResponse, error)
+		if params.UpdatedTo != nil {
 
-	PostUserWithResponse(ctx context.Context, params *PostUserParams, body PostUserJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUserResponse, error)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "updated_to", runtime.ParamLocationQuery, *params.UpdatedTo); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	// GetUserBannerWithResponse request
-	GetUserBannerWithResponse(ctx context.Context, params *GetUserBannerParams, reqEditors ...RequestEditorFn) (*GetUserBannerResponse, error)
-}
+		}
 
-type PostAuthResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *struct {
-		// Token Токен пользователя
-		Token *string `json:"token,omitempty"`
-	}
-	JSON400 *struct {
-		Error *string `json:"error,omitempty"`
-	}
-	JSON500 *struct {
-		Error *string `json:"error,omitempty"`
-	}
-}
+		if params.Q != nil {
 
-// Status returns HTTPResponse.Status
-func (r PostAuthResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostAuthResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+		}
 
-type GetBannerResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *[]struct {
-		// BannerId Идентификатор баннера
-		BannerId *int `json:"banner_id,omitempty"`
+		if params.Content != nil {
 
-		// Content Содержимое баннера
-		Content *map[string]interface{} `json:"content,omitempty"`
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "content", runtime.ParamLocationQuery, *params.Content); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-		// CreatedAt Дата создания баннера
-		CreatedAt *time.Time `json:"created_at,omitempty"`
+		}
 
-		// FeatureId Идентификатор фичи
-		FeatureId *int `json:"feature_id,omitempty"`
+		if params.ContentPath != nil {
 
-		// IsActive Флаг активности баннера
-		IsActive *bool `json:"is_active,omitempty"`
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "content_path", runtime.ParamLocationQuery, *params.ContentPath); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-		// TagIds Идентификаторы тэгов
-		TagIds *[]int `json:"tag_ids,omitempty"`
+		}
 
-		// UpdatedAt Дата обновления баннера
-		UpdatedAt *time.Time `json:"updated_at,omitempty"`
-	}
-	JSON500 *struct {
-		Error *string `json:"error,omitempty"`
-	}
-}
+		if params.Sort != nil {
 
-// Status returns HTTPResponse.Status
-func (r GetBannerResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetBannerResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+		}
 
-type PostBannerResponse struct {
+		if params.Order != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "order", runtime.ParamLocationQuery, *params.Order); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostBannerImportRequestWithBody generates requests for PostBannerImport with any type of body
+func NewPostBannerImportRequestWithBody(server string, params *PostBannerImportParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/banner/import")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Mode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "mode", runtime.ParamLocationQuery, *params.Mode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Upsert != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "upsert", runtime.ParamLocationQuery, *params.Upsert); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.DryRun != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "dry_run", runtime.ParamLocationQuery, *params.DryRun); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteBannerIdRequest generates requests for DeleteBannerId
+func NewDeleteBannerIdRequest(server string, id int, params *DeleteBannerIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/banner/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPatchBannerIdRequest calls the generic PatchBannerId builder with application/json body
+func NewPatchBannerIdRequest(server string, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchBannerIdRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewPatchBannerIdRequestWithBody generates requests for PatchBannerId with any type of body
+func NewPatchBannerIdRequestWithBody(server string, id int, params *PatchBannerIdParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/banner/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetBannerIdStatsRequest generates requests for GetBannerIdStats
+func NewGetBannerIdStatsRequest(server string, id int, params *GetBannerIdStatsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/banner/%s/stats", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetDocsRequest generates requests for GetDocs
+func NewGetDocsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/docs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostEventsClickRequest calls the generic PostEventsClick builder with application/json body
+func NewPostEventsClickRequest(server string, params *PostEventsClickParams, body PostEventsClickJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostEventsClickRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostEventsClickRequestWithBody generates requests for PostEventsClick with any type of body
+func NewPostEventsClickRequestWithBody(server string, params *PostEventsClickParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/events/click")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetHealthzRequest generates requests for GetHealthz
+func NewGetHealthzRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/healthz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLivezRequest generates requests for GetLivez
+func NewGetLivezRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/livez")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetReadyzRequest generates requests for GetReadyz
+func NewGetReadyzRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/readyz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostUserRequest calls the generic PostUser builder with application/json body
+func NewPostUserRequest(server string, params *PostUserParams, body PostUserJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUserRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostUserRequestWithBody generates requests for PostUser with any type of body
+func NewPostUserRequestWithBody(server string, params *PostUserParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetUserBannerRequest generates requests for GetUserBanner
+func NewGetUserBannerRequest(server string, params *GetUserBannerParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user_banner")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_id", runtime.ParamLocationQuery, params.TagId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_id", runtime.ParamLocationQuery, params.FeatureId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.UseLastRevision != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "use_last_revision", runtime.ParamLocationQuery, *params.UseLastRevision); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+		if params.IfNoneMatch != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostUserBannerBatchRequest calls the generic PostUserBannerBatch builder with application/json body
+func NewPostUserBannerBatchRequest(server string, params *PostUserBannerBatchParams, body PostUserBannerBatchJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUserBannerBatchRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostUserBannerBatchRequestWithBody generates requests for PostUserBannerBatch with any type of body
+func NewPostUserBannerBatchRequestWithBody(server string, params *PostUserBannerBatchParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user_banner/batch")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetUserBannerStreamRequest generates requests for GetUserBannerStream
+func NewGetUserBannerStreamRequest(server string, params *GetUserBannerStreamParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user_banner/stream")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag_id", runtime.ParamLocationQuery, params.TagId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "feature_id", runtime.ParamLocationQuery, params.FeatureId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+		if params.LastEventID != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "Last-Event-ID", runtime.ParamLocationHeader, *params.LastEventID)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Last-Event-ID", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetWebhooksRequest generates requests for GetWebhooks
+func NewGetWebhooksRequest(server string, params *GetWebhooksParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPostWebhooksRequest calls the generic PostWebhooks builder with application/json body
+func NewPostWebhooksRequest(server string, params *PostWebhooksParams, body PostWebhooksJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostWebhooksRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostWebhooksRequestWithBody generates requests for PostWebhooks with any type of body
+func NewPostWebhooksRequestWithBody(server string, params *PostWebhooksParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteWebhooksIdRequest generates requests for DeleteWebhooksId
+func NewDeleteWebhooksIdRequest(server string, id int, params *DeleteWebhooksIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetWebhooksIdDeliveriesRequest generates requests for GetWebhooksIdDeliveries
+func NewGetWebhooksIdDeliveriesRequest(server string, id int, params *GetWebhooksIdDeliveriesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/%s/deliveries", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.Token != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "token", runtime.ParamLocationHeader, *params.Token)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("token", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAdminLogLevelWithResponse request
+	GetAdminLogLevelWithResponse(ctx context.Context, params *GetAdminLogLevelParams, reqEditors ...RequestEditorFn) (*GetAdminLogLevelResponse, error)
+
+	// PutAdminLogLevelWithBodyWithResponse request with any body
+	PutAdminLogLevelWithBodyWithResponse(ctx context.Context, params *PutAdminLogLevelParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminLogLevelResponse, error)
+
+	PutAdminLogLevelWithResponse(ctx context.Context, params *PutAdminLogLevelParams, body PutAdminLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminLogLevelResponse, error)
+
+	// PostAuthWithBodyWithResponse request with any body
+	PostAuthWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthResponse, error)
+
+	PostAuthWithResponse(ctx context.Context, body PostAuthJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthResponse, error)
+
+	// GetBannerWithResponse request
+	GetBannerWithResponse(ctx context.Context, params *GetBannerParams, reqEditors ...RequestEditorFn) (*GetBannerResponse, error)
+
+	// PostBannerWithBodyWithResponse request with any body
+	PostBannerWithBodyWithResponse(ctx context.Context, params *PostBannerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBannerResponse, error)
+
+	PostBannerWithResponse(ctx context.Context, params *PostBannerParams, body PostBannerJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBannerResponse, error)
+
+	// GetBannerExportWithResponse request
+	GetBannerExportWithResponse(ctx context.Context, params *GetBannerExportParams, reqEditors ...RequestEditorFn) (*GetBannerExportResponse, error)
+
+	// PostBannerImportWithBodyWithResponse request with any body
+	PostBannerImportWithBodyWithResponse(ctx context.Context, params *PostBannerImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBannerImportResponse, error)
+
+	// DeleteBannerIdWithResponse request
+	DeleteBannerIdWithResponse(ctx context.Context, id int, params *DeleteBannerIdParams, reqEditors ...RequestEditorFn) (*DeleteBannerIdResponse, error)
+
+	// PatchBannerIdWithBodyWithResponse request with any body
+	PatchBannerIdWithBodyWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error)
+
+	PatchBannerIdWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error)
+
+	// GetBannerIdStatsWithResponse request
+	GetBannerIdStatsWithResponse(ctx context.Context, id int, params *GetBannerIdStatsParams, reqEditors ...RequestEditorFn) (*GetBannerIdStatsResponse, error)
+
+	// GetDocsWithResponse request
+	GetDocsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDocsResponse, error)
+
+	// PostEventsClickWithBodyWithResponse request with any body
+	PostEventsClickWithBodyWithResponse(ctx context.Context, params *PostEventsClickParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEventsClickResponse, error)
+
+	PostEventsClickWithResponse(ctx context.Context, params *PostEventsClickParams, body PostEventsClickJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEventsClickResponse, error)
+
+	// GetHealthzWithResponse request
+	GetHealthzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthzResponse, error)
+
+	// GetLivezWithResponse request
+	GetLivezWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLivezResponse, error)
+
+	// GetReadyzWithResponse request
+	GetReadyzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadyzResponse, error)
+
+	// PostUserWithBodyWithResponse request with any body
+	PostUserWithBodyWithResponse(ctx context.Context, params *PostUserParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUserResponse, error)
+
+	PostUserWithResponse(ctx context.Context, params *PostUserParams, body PostUserJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUserResponse, error)
+
+	// GetUserBannerWithResponse request
+	GetUserBannerWithResponse(ctx context.Context, params *GetUserBannerParams, reqEditors ...RequestEditorFn) (*GetUserBannerResponse, error)
+
+	// PostUserBannerBatchWithBodyWithResponse request with any body
+	PostUserBannerBatchWithBodyWithResponse(ctx context.Context, params *PostUserBannerBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUserBannerBatchResponse, error)
+
+	PostUserBannerBatchWithResponse(ctx context.Context, params *PostUserBannerBatchParams, body PostUserBannerBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUserBannerBatchResponse, error)
+
+	// GetUserBannerStreamWithResponse request
+	GetUserBannerStreamWithResponse(ctx context.Context, params *GetUserBannerStreamParams, reqEditors ...RequestEditorFn) (*GetUserBannerStreamResponse, error)
+
+	// GetWebhooksWithResponse request
+	GetWebhooksWithResponse(ctx context.Context, params *GetWebhooksParams, reqEditors ...RequestEditorFn) (*GetWebhooksResponse, error)
+
+	// PostWebhooksWithBodyWithResponse request with any body
+	PostWebhooksWithBodyWithResponse(ctx context.Context, params *PostWebhooksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostWebhooksResponse, error)
+
+	PostWebhooksWithResponse(ctx context.Context, params *PostWebhooksParams, body PostWebhooksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostWebhooksResponse, error)
+
+	// DeleteWebhooksIdWithResponse request
+	DeleteWebhooksIdWithResponse(ctx context.Context, id int, params *DeleteWebhooksIdParams, reqEditors ...RequestEditorFn) (*DeleteWebhooksIdResponse, error)
+
+	// GetWebhooksIdDeliveriesWithResponse request
+	GetWebhooksIdDeliveriesWithResponse(ctx context.Context, id int, params *GetWebhooksIdDeliveriesParams, reqEditors ...RequestEditorFn) (*GetWebhooksIdDeliveriesResponse, error)
+}
+
+type GetAdminLogLevelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LogLevel
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAdminLogLevelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAdminLogLevelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutAdminLogLevelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LogLevel
+}
+
+// Status returns HTTPResponse.Status
+func (r PutAdminLogLevelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutAdminLogLevelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Token Токен пользователя
+		Token *string `json:"token,omitempty"`
+	}
+	JSON400 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBannerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]struct {
+		// BannerId Идентификатор баннера
+		BannerId *int `json:"banner_id,omitempty"`
+
+		// Content Содержимое баннера
+		Content *map[string]interface{} `json:"content,omitempty"`
+
+		// CreatedAt Дата создания баннера
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+
+		// FeatureId Идентификатор фичи
+		FeatureId *int `json:"feature_id,omitempty"`
+
+		// IsActive Флаг активности баннера
+		IsActive *bool `json:"is_active,omitempty"`
+
+		// TagIds Идентификаторы тэгов
+		TagIds *[]int `json:"tag_ids,omitempty"`
+
+		// UpdatedAt Дата обновления баннера
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	}
+	JSON400 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBannerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBannerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostBannerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		// BannerId Идентификатор созданного баннера
+		BannerId *int `json:"banner_id,omitempty"`
+	}
+	JSON400 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostBannerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostBannerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBannerExportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBannerExportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBannerExportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostBannerImportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ImportReport
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON422 *ImportReport
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostBannerImportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostBannerImportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteBannerIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteBannerIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteBannerIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchBannerIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchBannerIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchBannerIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBannerIdStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BannerStats
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBannerIdStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBannerIdStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDocsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDocsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDocsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostEventsClickResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostEventsClickResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostEventsClickResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthzResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// IsLeader Является ли экземпляр лидером
+		IsLeader *bool `json:"is_leader,omitempty"`
+
+		// Leader Идентификатор текущего лидера
+		Leader *string `json:"leader,omitempty"`
+
+		// NodeId Идентификатор экземпляра
+		NodeId *string `json:"node_id,omitempty"`
+
+		// Status Состояние экземпляра
+		Status *string `json:"status,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthzResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthzResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLivezResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Status *string `json:"status,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLivezResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLivezResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReadyzResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Readiness
+	JSON503      *Readiness
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReadyzResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReadyzResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *struct {
+		// Token Токен пользователя
+		Token *string `json:"token,omitempty"`
+	}
+	JSON400 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUserBannerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUserBannerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUserBannerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUserBannerBatchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Banners Результат по идентификатору фичи
+		Banners *map[string]BatchBanner `json:"banners,omitempty"`
+	}
+	JSON400 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUserBannerBatchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUserBannerBatchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUserBannerStreamResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON500      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUserBannerStreamResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUserBannerStreamResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhooksResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON201      *struct {
-		// BannerId Идентификатор созданного баннера
-		BannerId *int `json:"banner_id,omitempty"`
+	JSON200      *[]Webhook
+	JSON500      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhooksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhooksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostWebhooksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Webhook
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r PostWebhooksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostWebhooksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteWebhooksIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON500      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteWebhooksIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteWebhooksIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhooksIdDeliveriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]WebhookDelivery
+	JSON400      *struct {
+		Error *string `json:"error,omitempty"`
+	}
+	JSON500 *struct {
+		Error *string `json:"error,omitempty"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhooksIdDeliveriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhooksIdDeliveriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAdminLogLevelWithResponse request returning *GetAdminLogLevelResponse
+func (c *ClientWithResponses) GetAdminLogLevelWithResponse(ctx context.Context, params *GetAdminLogLevelParams, reqEditors ...RequestEditorFn) (*GetAdminLogLevelResponse, error) {
+	rsp, err := c.GetAdminLogLevel(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAdminLogLevelResponse(rsp)
+}
+
+// PutAdminLogLevelWithBodyWithResponse request with arbitrary body returning *PutAdminLogLevelResponse
+func (c *ClientWithResponses) PutAdminLogLevelWithBodyWithResponse(ctx context.Context, params *PutAdminLogLevelParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutAdminLogLevelResponse, error) {
+	rsp, err := c.PutAdminLogLevelWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminLogLevelResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutAdminLogLevelWithResponse(ctx context.Context, params *PutAdminLogLevelParams, body PutAdminLogLevelJSONRequestBody, reqEditors ...RequestEditorFn) (*PutAdminLogLevelResponse, error) {
+	rsp, err := c.PutAdminLogLevel(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutAdminLogLevelResponse(rsp)
+}
+
+// PostAuthWithBodyWithResponse request with arbitrary body returning *PostAuthResponse
+func (c *ClientWithResponses) PostAuthWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthResponse, error) {
+	rsp, err := c.PostAuthWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthWithResponse(ctx context.Context, body PostAuthJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthResponse, error) {
+	rsp, err := c.PostAuth(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthResponse(rsp)
+}
+
+// GetBannerWithResponse request returning *GetBannerResponse
+func (c *ClientWithResponses) GetBannerWithResponse(ctx context.Context, params *GetBannerParams, reqEditors ...RequestEditorFn) (*GetBannerResponse, error) {
+	rsp, err := c.GetBanner(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBannerResponse(rsp)
+}
+
+// PostBannerWithBodyWithResponse request with arbitrary body returning *PostBannerResponse
+func (c *ClientWithResponses) PostBannerWithBodyWithResponse(ctx context.Context, params *PostBannerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBannerResponse, error) {
+	rsp, err := c.PostBannerWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBannerResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostBannerWithResponse(ctx context.Context, params *PostBannerParams, body PostBannerJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBannerResponse, error) {
+	rsp, err := c.PostBanner(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBannerResponse(rsp)
+}
+
+// GetBannerExportWithResponse request returning *GetBannerExportResponse
+func (c *ClientWithResponses) GetBannerExportWithResponse(ctx context.Context, params *GetBannerExportParams, reqEditors ...RequestEditorFn) (*GetBannerExportResponse, error) {
+	rsp, err := c.GetBannerExport(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBannerExportResponse(rsp)
+}
+
+// PostBannerImportWithBodyWithResponse request with arbitrary body returning *PostBannerImportResponse
+func (c *ClientWithResponses) PostBannerImportWithBodyWithResponse(ctx context.Context, params *PostBannerImportParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBannerImportResponse, error) {
+	rsp, err := c.PostBannerImportWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostBannerImportResponse(rsp)
+}
+
+// DeleteBannerIdWithResponse request returning *DeleteBannerIdResponse
+func (c *ClientWithResponses) DeleteBannerIdWithResponse(ctx context.Context, id int, params *DeleteBannerIdParams, reqEditors ...RequestEditorFn) (*DeleteBannerIdResponse, error) {
+	rsp, err := c.DeleteBannerId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteBannerIdResponse(rsp)
+}
+
+// PatchBannerIdWithBodyWithResponse request with arbitrary body returning *PatchBannerIdResponse
+func (c *ClientWithResponses) PatchBannerIdWithBodyWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error) {
+	rsp, err := c.PatchBannerIdWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchBannerIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchBannerIdWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error) {
+	rsp, err := c.PatchBannerId(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchBannerIdResponse(rsp)
+}
+
+// GetBannerIdStatsWithResponse request returning *GetBannerIdStatsResponse
+func (c *ClientWithResponses) GetBannerIdStatsWithResponse(ctx context.Context, id int, params *GetBannerIdStatsParams, reqEditors ...RequestEditorFn) (*GetBannerIdStatsResponse, error) {
+	rsp, err := c.GetBannerIdStats(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBannerIdStatsResponse(rsp)
+}
+
+// GetDocsWithResponse request returning *GetDocsResponse
+func (c *ClientWithResponses) GetDocsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDocsResponse, error) {
+	rsp, err := c.GetDocs(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDocsResponse(rsp)
+}
+
+// PostEventsClickWithBodyWithResponse request with arbitrary body returning *PostEventsClickResponse
+func (c *ClientWithResponses) PostEventsClickWithBodyWithResponse(ctx context.Context, params *PostEventsClickParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEventsClickResponse, error) {
+	rsp, err := c.PostEventsClickWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEventsClickResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostEventsClickWithResponse(ctx context.Context, params *PostEventsClickParams, body PostEventsClickJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEventsClickResponse, error) {
+	rsp, err := c.PostEventsClick(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEventsClickResponse(rsp)
+}
+
+// GetHealthzWithResponse request returning *GetHealthzResponse
+func (c *ClientWithResponses) GetHealthzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthzResponse, error) {
+	rsp, err := c.GetHealthz(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthzResponse(rsp)
+}
+
+// GetLivezWithResponse request returning *GetLivezResponse
+func (c *ClientWithResponses) GetLivezWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetLivezResponse, error) {
+	rsp, err := c.GetLivez(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLivezResponse(rsp)
+}
+
+// GetReadyzWithResponse request returning *GetReadyzResponse
+func (c *ClientWithResponses) GetReadyzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadyzResponse, error) {
+	rsp, err := c.GetReadyz(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReadyzResponse(rsp)
+}
+
+// PostUserWithBodyWithResponse request with arbitrary body returning *PostUserResponse
+func (c *ClientWithResponses) PostUserWithBodyWithResponse(ctx context.Context, params *PostUserParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUserResponse, error) {
+	rsp, err := c.PostUserWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUserResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUserWithResponse(ctx context.Context, params *PostUserParams, body PostUserJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUserResponse, error) {
+	rsp, err := c.PostUser(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUserResponse(rsp)
+}
+
+// GetUserBannerWithResponse request returning *GetUserBannerResponse
+func (c *ClientWithResponses) GetUserBannerWithResponse(ctx context.Context, params *GetUserBannerParams, reqEditors ...RequestEditorFn) (*GetUserBannerResponse, error) {
+	rsp, err := c.GetUserBanner(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUserBannerResponse(rsp)
+}
+
+// PostUserBannerBatchWithBodyWithResponse request with arbitrary body returning *PostUserBannerBatchResponse
+func (c *ClientWithResponses) PostUserBannerBatchWithBodyWithResponse(ctx context.Context, params *PostUserBannerBatchParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUserBannerBatchResponse, error) {
+	rsp, err := c.PostUserBannerBatchWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	JSON400 *struct {
-		Error *string `json:"error,omitempty"`
+	return ParsePostUserBannerBatchResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUserBannerBatchWithResponse(ctx context.Context, params *PostUserBannerBatchParams, body PostUserBannerBatchJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUserBannerBatchResponse, error) {
+	rsp, err := c.PostUserBannerBatch(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	JSON500 *struct {
-		Error *string `json:"error,omitempty"`
+	return ParsePostUserBannerBatchResponse(rsp)
+}
+
+// GetUserBannerStreamWithResponse request returning *GetUserBannerStreamResponse
+func (c *ClientWithResponses) GetUserBannerStreamWithResponse(ctx context.Context, params *GetUserBannerStreamParams, reqEditors ...RequestEditorFn) (*GetUserBannerStreamResponse, error) {
+	rsp, err := c.GetUserBannerStream(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
+	return ParseGetUserBannerStreamResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r PostBannerResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// GetWebhooksWithResponse request returning *GetWebhooksResponse
+func (c *ClientWithResponses) GetWebhooksWithResponse(ctx context.Context, params *GetWebhooksParams, reqEditors ...RequestEditorFn) (*GetWebhooksResponse, error) {
+	rsp, err := c.GetWebhooks(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseGetWebhooksResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostBannerResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// PostWebhooksWithBodyWithResponse request with arbitrary body returning *PostWebhooksResponse
+func (c *ClientWithResponses) PostWebhooksWithBodyWithResponse(ctx context.Context, params *PostWebhooksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostWebhooksResponse, error) {
+	rsp, err := c.PostWebhooksWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return 0
+	return ParsePostWebhooksResponse(rsp)
 }
 
-type DeleteBannerIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *struct {
-		Error *string `json:"error,omitempty"`
+func (c *ClientWithResponses) PostWebhooksWithResponse(ctx context.Context, params *PostWebhooksParams, body PostWebhooksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostWebhooksResponse, error) {
+	rsp, err := c.PostWebhooks(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	JSON500 *struct {
-		Error *string `json:"error,omitempty"`
+	return ParsePostWebhooksResponse(rsp)
+}
+
+// DeleteWebhooksIdWithResponse request returning *DeleteWebhooksIdResponse
+func (c *ClientWithResponses) DeleteWebhooksIdWithResponse(ctx context.Context, id int, params *DeleteWebhooksIdParams, reqEditors ...RequestEditorFn) (*DeleteWebhooksIdResponse, error) {
+	rsp, err := c.DeleteWebhooksId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
+	return ParseDeleteWebhooksIdResponse(rsp)
 }
 
-// Status returns HTTPResponse.Status
-func (r DeleteBannerIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// GetWebhooksIdDeliveriesWithResponse request returning *GetWebhooksIdDeliveriesResponse
+func (c *ClientWithResponses) GetWebhooksIdDeliveriesWithResponse(ctx context.Context, id int, params *GetWebhooksIdDeliveriesParams, reqEditors ...RequestEditorFn) (*GetWebhooksIdDeliveriesResponse, error) {
+	rsp, err := c.GetWebhooksIdDeliveries(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+	return ParseGetWebhooksIdDeliveriesResponse(rsp)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r DeleteBannerIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParseGetAdminLogLevelResponse parses an HTTP response from a GetAdminLogLevelWithResponse call
+func ParseGetAdminLogLevelResponse(rsp *http.Response) (*GetAdminLogLevelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return 0
+
+	response := &GetAdminLogLevelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LogLevel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
 
-type PatchBannerIdResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *struct {
-		Error *string `json:"error,omitempty"`
+// ParsePutAdminLogLevelResponse parses an HTTP response from a PutAdminLogLevelWithResponse call
+func ParsePutAdminLogLevelResponse(rsp *http.Response) (*PutAdminLogLevelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	JSON500 *struct {
-		Error *string `json:"error,omitempty"`
+
+	response := &PutAdminLogLevelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LogLevel
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthResponse parses an HTTP response from a PostAuthWithResponse call
+func ParsePostAuthResponse(rsp *http.Response) (*PostAuthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Token Токен пользователя
+			Token *string `json:"token,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBannerResponse parses an HTTP response from a GetBannerWithResponse call
+func ParseGetBannerResponse(rsp *http.Response) (*GetBannerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBannerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []struct {
+			// BannerId Идентификатор баннера
+			BannerId *int `json:"banner_id,omitempty"`
+
+			// Content Содержимое баннера
+			Content *map[string]interface{} `json:"content,omitempty"`
+
+			// CreatedAt Дата создания баннера
+			CreatedAt *time.Time `json:"created_at,omitempty"`
+
+			// FeatureId Идентификатор фичи
+			FeatureId *int `json:"feature_id,omitempty"`
+
+			// IsActive Флаг активности баннера
+			IsActive *bool `json:"is_active,omitempty"`
+
+			// TagIds Идентификаторы тэгов
+			TagIds *[]int `json:"tag_ids,omitempty"`
+
+			// UpdatedAt Дата обновления баннера
+			UpdatedAt *time.Time `json:"updated_at,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostBannerResponse parses an HTTP response from a PostBannerWithResponse call
+func ParsePostBannerResponse(rsp *http.Response) (*PostBannerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostBannerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest struct {
+			// BannerId Идентификатор созданного баннера
+			BannerId *int `json:"banner_id,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r PatchBannerIdResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseGetBannerExportResponse parses an HTTP response from a GetBannerExportWithResponse call
+func ParseGetBannerExportResponse(rsp *http.Response) (*GetBannerExportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PatchBannerIdResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &GetBannerExportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GetDocsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
 
-// Status returns HTTPResponse.Status
-func (r GetDocsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetDocsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
-}
 
-type PostUserResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *struct {
-		// Token Токен пользователя
-		Token *string `json:"token,omitempty"`
-	}
-	JSON400 *struct {
-		Error *string `json:"error,omitempty"`
-	}
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r PostUserResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParsePostBannerImportResponse parses an HTTP response from a PostBannerImportWithResponse call
+func ParsePostBannerImportResponse(rsp *http.Response) (*PostBannerImportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r PostUserResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &PostBannerImportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type GetUserBannerResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *map[string]interface{}
-	JSON400      *struct {
-		Error *string `json:"error,omitempty"`
-	}
-	JSON500 *struct {
-		Error *string `json:"error,omitempty"`
-	}
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ImportReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r GetUserBannerResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest ImportReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r GetUserBannerResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
+
+	return response, nil
 }
 
-// PostAuthWithBodyWithResponse request with arbitrary body returning *PostAuthResponse
-func (c *ClientWithResponses) PostAuthWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthResponse, error) {
-	rsp, err := c.PostAuthWithBody(ctx, contentType, body, reqEditors...)
+// ParseDeleteBannerIdResponse parses an HTTP response from a DeleteBannerIdWithResponse call
+func ParseDeleteBannerIdResponse(rsp *http.Response) (*DeleteBannerIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostAuthResponse(rsp)
-}
 
-func (c *ClientWithResponses) PostAuthWithResponse(ctx context.Context, body PostAuthJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthResponse, error) {
-	rsp, err := c.PostAuth(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &DeleteBannerIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePostAuthResponse(rsp)
-}
 
-// GetBannerWithResponse request returning *GetBannerResponse
-func (c *ClientWithResponses) GetBannerWithResponse(ctx context.Context, params *GetBannerParams, reqEditors ...RequestEditorFn) (*GetBannerResponse, error) {
-	rsp, err := c.GetBanner(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
-	return ParseGetBannerResponse(rsp)
+
+	return response, nil
 }
 
-// PostBannerWithBodyWithResponse request with arbitrary body returning *PostBannerResponse
-func (c *ClientWithResponses) PostBannerWithBodyWithResponse(ctx context.Context, params *PostBannerParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostBannerResponse, error) {
-	rsp, err := c.PostBannerWithBody(ctx, params, contentType, body, reqEditors...)
+// ParsePatchBannerIdResponse parses an HTTP response from a PatchBannerIdWithResponse call
+func ParsePatchBannerIdResponse(rsp *http.Response) (*PatchBannerIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostBannerResponse(rsp)
-}
 
-func (c *ClientWithResponses) PostBannerWithResponse(ctx context.Context, params *PostBannerParams, body PostBannerJSONRequestBody, reqEditors ...RequestEditorFn) (*PostBannerResponse, error) {
-	rsp, err := c.PostBanner(ctx, params, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &PatchBannerIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePostBannerResponse(rsp)
-}
 
-// DeleteBannerIdWithResponse request returning *DeleteBannerIdResponse
-func (c *ClientWithResponses) DeleteBannerIdWithResponse(ctx context.Context, id int, params *DeleteBannerIdParams, reqEditors ...RequestEditorFn) (*DeleteBannerIdResponse, error) {
-	rsp, err := c.DeleteBannerId(ctx, id, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
-	return ParseDeleteBannerIdResponse(rsp)
+
+	return response, nil
 }
 
-// PatchBannerIdWithBodyWithResponse request with arbitrary body returning *PatchBannerIdResponse
-func (c *ClientWithResponses) PatchBannerIdWithBodyWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error) {
-	rsp, err := c.PatchBannerIdWithBody(ctx, id, params, contentType, body, reqEditors...)
+// ParseGetBannerIdStatsResponse parses an HTTP response from a GetBannerIdStatsWithResponse call
+func ParseGetBannerIdStatsResponse(rsp *http.Response) (*GetBannerIdStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePatchBannerIdResponse(rsp)
-}
 
-func (c *ClientWithResponses) PatchBannerIdWithResponse(ctx context.Context, id int, params *PatchBannerIdParams, body PatchBannerIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchBannerIdResponse, error) {
-	rsp, err := c.PatchBannerId(ctx, id, params, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetBannerIdStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BannerStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
-	return ParsePatchBannerIdResponse(rsp)
-}
 
-// GetDocsWithResponse request returning *GetDocsResponse
-func (c *ClientWithResponses) GetDocsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDocsResponse, error) {
-	rsp, err := c.GetDocs(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseGetDocsResponse(rsp)
+	return response, nil
 }
 
-// PostUserWithBodyWithResponse request with arbitrary body returning *PostUserResponse
-func (c *ClientWithResponses) PostUserWithBodyWithResponse(ctx context.Context, params *PostUserParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUserResponse, error) {
-	rsp, err := c.PostUserWithBody(ctx, params, contentType, body, reqEditors...)
+// ParseGetDocsResponse parses an HTTP response from a GetDocsWithResponse call
+func ParseGetDocsResponse(rsp *http.Response) (*GetDocsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParsePostUserResponse(rsp)
-}
 
-func (c *ClientWithResponses) PostUserWithResponse(ctx context.Context, params *PostUserParams, body PostUserJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUserResponse, error) {
-	rsp, err := c.PostUser(ctx, params, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &GetDocsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParsePostUserResponse(rsp)
-}
 
-// GetUserBannerWithResponse request returning *GetUserBannerResponse
-func (c *ClientWithResponses) GetUserBannerWithResponse(ctx context.Context, params *GetUserBannerParams, reqEditors ...RequestEditorFn) (*GetUserBannerResponse, error) {
-	rsp, err := c.GetUserBanner(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseGetUserBannerResponse(rsp)
+	return response, nil
 }
 
-// ParsePostAuthResponse parses an HTTP response from a PostAuthWithResponse call
-func ParsePostAuthResponse(rsp *http.Response) (*PostAuthResponse, error) {
+// ParsePostEventsClickResponse parses an HTTP response from a PostEventsClickWithResponse call
+func ParsePostEventsClickResponse(rsp *http.Response) (*PostEventsClickResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostAuthResponse{
+	response := &PostEventsClickResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest struct {
-			// Token Токен пользователя
-			Token *string `json:"token,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest struct {
 			Error *string `json:"error,omitempty"`
@@ -1221,71 +3920,114 @@ func ParsePostAuthResponse(rsp *http.Response) (*PostAuthResponse, error) {
 	return response, nil
 }
 
-// ParseGetBannerResponse parses an HTTP response from a GetBannerWithResponse call
-func ParseGetBannerResponse(rsp *http.Response) (*GetBannerResponse, error) {
+// ParseGetHealthzResponse parses an HTTP response from a GetHealthzWithResponse call
+func ParseGetHealthzResponse(rsp *http.Response) (*GetHealthzResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetBannerResponse{
+	response := &GetHealthzResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []struct {
-			// BannerId Идентификатор баннера
-			BannerId *int `json:"banner_id,omitempty"`
+		var dest struct {
+			// IsLeader Является ли экземпляр лидером
+			IsLeader *bool `json:"is_leader,omitempty"`
 
-			// Content Содержимое баннера
-			Content *map[string]interface{} `json:"content,omitempty"`
+			// Leader Идентификатор текущего лидера
+			Leader *string `json:"leader,omitempty"`
 
-			// CreatedAt Дата создания баннера
-			CreatedAt *time.Time `json:"created_at,omitempty"`
+			// NodeId Идентификатор экземпляра
+			NodeId *string `json:"node_id,omitempty"`
 
-			// FeatureId Идентификатор фичи
-			FeatureId *int `json:"feature_id,omitempty"`
+			// Status Состояние экземпляра
+			Status *string `json:"status,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-			// IsActive Флаг активности баннера
-			IsActive *bool `json:"is_active,omitempty"`
+	}
 
-			// TagIds Идентификаторы тэгов
-			TagIds *[]int `json:"tag_ids,omitempty"`
+	return response, nil
+}
 
-			// UpdatedAt Дата обновления баннера
-			UpdatedAt *time.Time `json:"updated_at,omitempty"`
+// ParseGetLivezResponse parses an HTTP response from a GetLivezWithResponse call
+func ParseGetLivezResponse(rsp *http.Response) (*GetLivezResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLivezResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Status *string `json:"status,omitempty"`
 		}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Error *string `json:"error,omitempty"`
+	}
+
+	return response, nil
+}
+
+// ParseGetReadyzResponse parses an HTTP response from a GetReadyzWithResponse call
+func ParseGetReadyzResponse(rsp *http.Response) (*GetReadyzResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReadyzResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Readiness
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
 		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Readiness
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON500 = &dest
+		response.JSON503 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParsePostBannerResponse parses an HTTP response from a PostBannerWithResponse call
-func ParsePostBannerResponse(rsp *http.Response) (*PostBannerResponse, error) {
+// ParsePostUserResponse parses an HTTP response from a PostUserWithResponse call
+func ParsePostUserResponse(rsp *http.Response) (*PostUserResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostBannerResponse{
+	response := &PostUserResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
@@ -1293,8 +4035,8 @@ func ParsePostBannerResponse(rsp *http.Response) (*PostBannerResponse, error) {
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
 		var dest struct {
-			// BannerId Идентификатор созданного баннера
-			BannerId *int `json:"banner_id,omitempty"`
+			// Token Токен пользователя
+			Token *string `json:"token,omitempty"`
 		}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
@@ -1310,34 +4052,32 @@ func ParsePostBannerResponse(rsp *http.Response) (*PostBannerResponse, error) {
 		}
 		response.JSON400 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest struct {
-			Error *string `json:"error,omitempty"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
-
 	}
 
 	return response, nil
 }
 
-// ParseDeleteBannerIdResponse parses an HTTP response from a DeleteBannerIdWithResponse call
-func ParseDeleteBannerIdResponse(rsp *http.Response) (*DeleteBannerIdResponse, error) {
+// ParseGetUserBannerResponse parses an HTTP response from a GetUserBannerWithResponse call
+func ParseGetUserBannerResponse(rsp *http.Response) (*GetUserBannerResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteBannerIdResponse{
+	response := &GetUserBannerResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest struct {
 			Error *string `json:"error,omitempty"`
@@ -1361,20 +4101,30 @@ func ParseDeleteBannerIdResponse(rsp *http.Response) (*DeleteBannerIdResponse, e
 	return response, nil
 }
 
-// ParsePatchBannerIdResponse parses an HTTP response from a PatchBannerIdWithResponse call
-func ParsePatchBannerIdResponse(rsp *http.Response) (*PatchBannerIdResponse, error) {
+// ParsePostUserBannerBatchResponse parses an HTTP response from a PostUserBannerBatchWithResponse call
+func ParsePostUserBannerBatchResponse(rsp *http.Response) (*PostUserBannerBatchResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PatchBannerIdResponse{
+	response := &PostUserBannerBatchResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Banners Результат по идентификатору фичи
+			Banners *map[string]BatchBanner `json:"banners,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest struct {
 			Error *string `json:"error,omitempty"`
@@ -1398,44 +4148,88 @@ func ParsePatchBannerIdResponse(rsp *http.Response) (*PatchBannerIdResponse, err
 	return response, nil
 }
 
-// ParseGetDocsResponse parses an HTTP response from a GetDocsWithResponse call
-func ParseGetDocsResponse(rsp *http.Response) (*GetDocsResponse, error) {
+// ParseGetUserBannerStreamResponse parses an HTTP response from a GetUserBannerStreamWithResponse call
+func ParseGetUserBannerStreamResponse(rsp *http.Response) (*GetUserBannerStreamResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetDocsResponse{
+	response := &GetUserBannerStreamResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
 	return response, nil
 }
 
-// ParsePostUserResponse parses an HTTP response from a PostUserWithResponse call
-func ParsePostUserResponse(rsp *http.Response) (*PostUserResponse, error) {
+// ParseGetWebhooksResponse parses an HTTP response from a GetWebhooksWithResponse call
+func ParseGetWebhooksResponse(rsp *http.Response) (*GetWebhooksResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostUserResponse{
+	response := &GetWebhooksResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Webhook
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest struct {
-			// Token Токен пользователя
-			Token *string `json:"token,omitempty"`
+			Error *string `json:"error,omitempty"`
 		}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostWebhooksResponse parses an HTTP response from a PostWebhooksWithResponse call
+func ParsePostWebhooksResponse(rsp *http.Response) (*PostWebhooksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostWebhooksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Webhook
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
 		response.JSON201 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
@@ -1447,27 +4241,64 @@ func ParsePostUserResponse(rsp *http.Response) (*PostUserResponse, error) {
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseGetUserBannerResponse parses an HTTP response from a GetUserBannerWithResponse call
-func ParseGetUserBannerResponse(rsp *http.Response) (*GetUserBannerResponse, error) {
+// ParseDeleteWebhooksIdResponse parses an HTTP response from a DeleteWebhooksIdWithResponse call
+func ParseDeleteWebhooksIdResponse(rsp *http.Response) (*DeleteWebhooksIdResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetUserBannerResponse{
+	response := &DeleteWebhooksIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest struct {
+			Error *string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWebhooksIdDeliveriesResponse parses an HTTP response from a GetWebhooksIdDeliveriesWithResponse call
+func ParseGetWebhooksIdDeliveriesResponse(rsp *http.Response) (*GetWebhooksIdDeliveriesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWebhooksIdDeliveriesResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest map[string]interface{}
+		var dest []WebhookDelivery
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -3,6 +3,216 @@
 // Code generated by github.com/deepmap/oapi-codegen/v2 version v2.1.0 DO NOT EDIT.
 package oapi
 
+import (
+	"time"
+)
+
+// Defines values for CheckStatus.
+const (
+	Fail CheckStatus = "fail"
+	Ok   CheckStatus = "ok"
+)
+
+// Defines values for LogLevelLevel.
+const (
+	Debug LogLevelLevel = "debug"
+	Error LogLevelLevel = "error"
+	Info  LogLevelLevel = "info"
+	Warn  LogLevelLevel = "warn"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+)
+
+// Defines values for GetBannerParamsTagMatch.
+const (
+	GetBannerParamsTagMatchAll GetBannerParamsTagMatch = "all"
+	GetBannerParamsTagMatchAny GetBannerParamsTagMatch = "any"
+)
+
+// Defines values for GetBannerParamsSort.
+const (
+	GetBannerParamsSortCreatedAt GetBannerParamsSort = "created_at"
+	GetBannerParamsSortFeatureId GetBannerParamsSort = "feature_id"
+	GetBannerParamsSortId        GetBannerParamsSort = "id"
+	GetBannerParamsSortUpdatedAt GetBannerParamsSort = "updated_at"
+)
+
+// Defines values for GetBannerParamsOrder.
+const (
+	GetBannerParamsOrderAsc  GetBannerParamsOrder = "asc"
+	GetBannerParamsOrderDesc GetBannerParamsOrder = "desc"
+)
+
+// Defines values for GetBannerExportParamsTagMatch.
+const (
+	GetBannerExportParamsTagMatchAll GetBannerExportParamsTagMatch = "all"
+	GetBannerExportParamsTagMatchAny GetBannerExportParamsTagMatch = "any"
+)
+
+// Defines values for GetBannerExportParamsSort.
+const (
+	GetBannerExportParamsSortCreatedAt GetBannerExportParamsSort = "created_at"
+	GetBannerExportParamsSortFeatureId GetBannerExportParamsSort = "feature_id"
+	GetBannerExportParamsSortId        GetBannerExportParamsSort = "id"
+	GetBannerExportParamsSortUpdatedAt GetBannerExportParamsSort = "updated_at"
+)
+
+// Defines values for GetBannerExportParamsOrder.
+const (
+	GetBannerExportParamsOrderAsc  GetBannerExportParamsOrder = "asc"
+	GetBannerExportParamsOrderDesc GetBannerExportParamsOrder = "desc"
+)
+
+// Defines values for PostBannerImportParamsMode.
+const (
+	Atomic     PostBannerImportParamsMode = "atomic"
+	BestEffort PostBannerImportParamsMode = "best_effort"
+)
+
+// Defines values for PostWebhooksJSONBodyEvents.
+const (
+	Activated PostWebhooksJSONBodyEvents = "activated"
+	Created   PostWebhooksJSONBodyEvents = "created"
+	Deleted   PostWebhooksJSONBodyEvents = "deleted"
+	Updated   PostWebhooksJSONBodyEvents = "updated"
+)
+
+// Defines values for GetWebhooksIdDeliveriesParamsStatus.
+const (
+	GetWebhooksIdDeliveriesParamsStatusDelivered GetWebhooksIdDeliveriesParamsStatus = "delivered"
+	GetWebhooksIdDeliveriesParamsStatusFailed    GetWebhooksIdDeliveriesParamsStatus = "failed"
+	GetWebhooksIdDeliveriesParamsStatusPending   GetWebhooksIdDeliveriesParamsStatus = "pending"
+)
+
+// BannerStats defines model for BannerStats.
+type BannerStats struct {
+	BannerId *int64 `json:"banner_id,omitempty"`
+	Clicks   *int64 `json:"clicks,omitempty"`
+
+	// Ctr Доля кликов от показов, 0 без показов
+	Ctr         *float64   `json:"ctr,omitempty"`
+	From        *time.Time `json:"from,omitempty"`
+	Impressions *int64     `json:"impressions,omitempty"`
+
+	// Tags Статистика по тэгам пользователей
+	Tags *[]TagStats `json:"tags,omitempty"`
+	To   *time.Time  `json:"to,omitempty"`
+}
+
+// BatchBanner defines model for BatchBanner.
+type BatchBanner struct {
+	// Content JSON-отображение баннера
+	Content *map[string]interface{} `json:"content,omitempty"`
+
+	// Error Причина, по которой баннер не получен - banner not found или banner storage unavailable
+	Error *string `json:"error,omitempty"`
+}
+
+// Check defines model for Check.
+type Check struct {
+	Error     *string      `json:"error,omitempty"`
+	LatencyMs *float32     `json:"latency_ms,omitempty"`
+	Status    *CheckStatus `json:"status,omitempty"`
+
+	// Version Версия последней примененной миграции
+	Version *int64 `json:"version,omitempty"`
+
+	// Warm Кэш заполнен фоновым обновлением
+	Warm *bool `json:"warm,omitempty"`
+}
+
+// CheckStatus defines model for Check.Status.
+type CheckStatus string
+
+// ImportReport defines model for ImportReport.
+type ImportReport struct {
+	Created *int  `json:"created,omitempty"`
+	DryRun  *bool `json:"dry_run,omitempty"`
+	Errors  *[]struct {
+		Error *string `json:"error,omitempty"`
+		Line  *int    `json:"line,omitempty"`
+	} `json:"errors,omitempty"`
+	Failed *int `json:"failed,omitempty"`
+
+	// Total Число строк в файле
+	Total   *int `json:"total,omitempty"`
+	Updated *int `json:"updated,omitempty"`
+}
+
+// LogLevel defines model for LogLevel.
+type LogLevel struct {
+	Level LogLevelLevel `json:"level"`
+}
+
+// LogLevelLevel defines model for LogLevel.Level.
+type LogLevelLevel string
+
+// Readiness defines model for Readiness.
+type Readiness struct {
+	// Checks Результаты проверок по зависимостям (postgres, redis, migrations, cache)
+	Checks *map[string]Check `json:"checks,omitempty"`
+
+	// Draining Экземпляр завершает работу и не принимает новые запросы
+	Draining *bool `json:"draining,omitempty"`
+	Ready    *bool `json:"ready,omitempty"`
+}
+
+// TagStats defines model for TagStats.
+type TagStats struct {
+	Clicks      *int64   `json:"clicks,omitempty"`
+	Ctr         *float64 `json:"ctr,omitempty"`
+	Impressions *int64   `json:"impressions,omitempty"`
+	TagId       *int     `json:"tag_id,omitempty"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	Events     *[]string  `json:"events,omitempty"`
+	FeatureIds *[]int     `json:"feature_ids,omitempty"`
+	Secret     *string    `json:"secret,omitempty"`
+	Url        *string    `json:"url,omitempty"`
+	WebhookId  *int       `json:"webhook_id,omitempty"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    *int       `json:"attempts,omitempty"`
+	BannerId    *int64     `json:"banner_id,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+	DeliveryId  *int64     `json:"delivery_id,omitempty"`
+	Event       *string    `json:"event,omitempty"`
+	LastError   *string    `json:"last_error,omitempty"`
+
+	// NextAttemptAt Время следующей попытки для pending
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+
+	// ResponseCode Код ответа последней попытки
+	ResponseCode *int                   `json:"response_code,omitempty"`
+	Status       *WebhookDeliveryStatus `json:"status,omitempty"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// GetAdminLogLevelParams defines parameters for GetAdminLogLevel.
+type GetAdminLogLevelParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// PutAdminLogLevelParams defines parameters for PutAdminLogLevel.
+type PutAdminLogLevelParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
 // PostAuthJSONBody defines parameters for PostAuth.
 type PostAuthJSONBody struct {
 	// Password Пароль
@@ -16,13 +226,70 @@ type PostAuthJSONBody struct {
 type GetBannerParams struct {
 	FeatureId *int `form:"feature_id,omitempty" json:"feature_id,omitempty"`
 	TagId     *int `form:"tag_id,omitempty" json:"tag_id,omitempty"`
-	Limit     *int `form:"limit,omitempty" json:"limit,omitempty"`
-	Offset    *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// BannerIds Идентификаторы баннеров
+	BannerIds *[]int `form:"banner_ids,omitempty" json:"banner_ids,omitempty"`
+
+	// FeatureIds Идентификаторы фич, баннер должен относиться к одной из них
+	FeatureIds *[]int `form:"feature_ids,omitempty" json:"feature_ids,omitempty"`
+
+	// TagIds Идентификаторы тегов, сопоставляются по tag_match
+	TagIds *[]int `form:"tag_ids,omitempty" json:"tag_ids,omitempty"`
+
+	// TagMatch any - у баннера есть хотя бы один из тегов tag_id и tag_ids, all - у баннера есть все эти теги
+	TagMatch *GetBannerParamsTagMatch `form:"tag_match,omitempty" json:"tag_match,omitempty"`
+
+	// IsActive Флаг активности баннера
+	IsActive *bool `form:"is_active,omitempty" json:"is_active,omitempty"`
+
+	// CreatedFrom Баннеры, созданные не раньше указанного момента
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo Баннеры, созданные раньше указанного момента
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// UpdatedFrom Баннеры, обновленные не раньше указанного момента
+	UpdatedFrom *time.Time `form:"updated_from,omitempty" json:"updated_from,omitempty"`
+
+	// UpdatedTo Баннеры, обновленные раньше указанного момента
+	UpdatedTo *time.Time `form:"updated_to,omitempty" json:"updated_to,omitempty"`
+
+	// Q Полнотекстовый поиск по строковым значениям содержимого. Поддерживает синтаксис websearch: кавычки для фраз, or и минус для исключения слов
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Content Фильтр по содержимому в виде key=value, можно указать несколько. Значение разбирается как JSON, а если это не удается - как строка
+	Content *[]string `form:"content,omitempty" json:"content,omitempty"`
+
+	// ContentPath Предикат JSONPath по содержимому
+	ContentPath *string `form:"content_path,omitempty" json:"content_path,omitempty"`
+	Limit       *int    `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset      *int    `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Курсор следующей страницы из заголовка X-Next-Cursor предыдущего ответа
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Sort Поле сортировки, при равных значениях баннеры упорядочиваются по идентификатору
+	Sort *GetBannerParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Направление сортировки
+	Order *GetBannerParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// WithTotal Вернуть общее число подходящих баннеров в заголовке X-Total-Count
+	WithTotal *bool `form:"with_total,omitempty" json:"with_total,omitempty"`
 
 	// Token Токен админа
 	Token *string `json:"token,omitempty"`
 }
 
+// GetBannerParamsTagMatch defines parameters for GetBanner.
+type GetBannerParamsTagMatch string
+
+// GetBannerParamsSort defines parameters for GetBanner.
+type GetBannerParamsSort string
+
+// GetBannerParamsOrder defines parameters for GetBanner.
+type GetBannerParamsOrder string
+
 // PostBannerJSONBody defines parameters for PostBanner.
 type PostBannerJSONBody struct {
 	// Content Содержимое баннера
@@ -44,6 +311,84 @@ type PostBannerParams struct {
 	Token *string `json:"token,omitempty"`
 }
 
+// GetBannerExportParams defines parameters for GetBannerExport.
+type GetBannerExportParams struct {
+	FeatureId *int `form:"feature_id,omitempty" json:"feature_id,omitempty"`
+	TagId     *int `form:"tag_id,omitempty" json:"tag_id,omitempty"`
+
+	// BannerIds Идентификаторы баннеров
+	BannerIds *[]int `form:"banner_ids,omitempty" json:"banner_ids,omitempty"`
+
+	// FeatureIds Идентификаторы фич, баннер должен относиться к одной из них
+	FeatureIds *[]int `form:"feature_ids,omitempty" json:"feature_ids,omitempty"`
+
+	// TagIds Идентификаторы тегов, сопоставляются по tag_match
+	TagIds *[]int `form:"tag_ids,omitempty" json:"tag_ids,omitempty"`
+
+	// TagMatch any - у баннера есть хотя бы один из тегов tag_id и tag_ids, all - у баннера есть все эти теги
+	TagMatch *GetBannerExportParamsTagMatch `form:"tag_match,omitempty" json:"tag_match,omitempty"`
+
+	// IsActive Флаг активности баннера
+	IsActive *bool `form:"is_active,omitempty" json:"is_active,omitempty"`
+
+	// CreatedFrom Баннеры, созданные не раньше указанного момента
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo Баннеры, созданные раньше указанного момента
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// UpdatedFrom Баннеры, обновленные не раньше указанного момента
+	UpdatedFrom *time.Time `form:"updated_from,omitempty" json:"updated_from,omitempty"`
+
+	// UpdatedTo Баннеры, обновленные раньше указанного момента
+	UpdatedTo *time.Time `form:"updated_to,omitempty" json:"updated_to,omitempty"`
+
+	// Q Полнотекстовый поиск по строковым значениям содержимого. Поддерживает синтаксис websearch: кавычки для фраз, or и минус для исключения слов
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// Content Фильтр по содержимому в виде key=value, можно указать несколько. Значение разбирается как JSON, а если это не удается - как строка
+	Content *[]string `form:"content,omitempty" json:"content,omitempty"`
+
+	// ContentPath Предикат JSONPath по содержимому
+	ContentPath *string `form:"content_path,omitempty" json:"content_path,omitempty"`
+
+	// Sort Поле сортировки, при равных значениях баннеры упорядочиваются по идентификатору
+	Sort *GetBannerExportParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Направление сортировки
+	Order *GetBannerExportParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// GetBannerExportParamsTagMatch defines parameters for GetBannerExport.
+type GetBannerExportParamsTagMatch string
+
+// GetBannerExportParamsSort defines parameters for GetBannerExport.
+type GetBannerExportParamsSort string
+
+// GetBannerExportParamsOrder defines parameters for GetBannerExport.
+type GetBannerExportParamsOrder string
+
+// PostBannerImportParams defines parameters for PostBannerImport.
+type PostBannerImportParams struct {
+	// Mode atomic - любая ошибка отменяет импорт целиком, best_effort - ошибочные строки пропускаются
+	Mode *PostBannerImportParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Upsert Обновлять баннеры с совпадающим external_key
+	Upsert *bool `form:"upsert,omitempty" json:"upsert,omitempty"`
+
+	// DryRun Проверить импорт и вернуть отчет без сохранения
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// PostBannerImportParamsMode defines parameters for PostBannerImport.
+type PostBannerImportParamsMode string
+
 // DeleteBannerIdParams defines parameters for DeleteBannerId.
 type DeleteBannerIdParams struct {
 	// Token Токен админа
@@ -71,6 +416,30 @@ type PatchBannerIdParams struct {
 	Token *string `json:"token,omitempty"`
 }
 
+// GetBannerIdStatsParams defines parameters for GetBannerIdStats.
+type GetBannerIdStatsParams struct {
+	// From Начало периода, по умолчанию - сутки до to
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец периода, по умолчанию - текущее время
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// PostEventsClickJSONBody defines parameters for PostEventsClick.
+type PostEventsClickJSONBody struct {
+	BannerId int64 `json:"banner_id"`
+	TagId    int   `json:"tag_id"`
+}
+
+// PostEventsClickParams defines parameters for PostEventsClick.
+type PostEventsClickParams struct {
+	// Token Токен пользователя
+	Token *string `json:"token,omitempty"`
+}
+
 // PostUserJSONBody defines parameters for PostUser.
 type PostUserJSONBody struct {
 	// FeatureId Идентификатор фичи
@@ -103,8 +472,97 @@ type GetUserBannerParams struct {
 
 	// Token Токен пользователя
 	Token *string `json:"token,omitempty"`
+
+	// IfNoneMatch ETag ранее полученного баннера
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PostUserBannerBatchJSONBody defines parameters for PostUserBannerBatch.
+type PostUserBannerBatchJSONBody struct {
+	// FeatureIds Идентификаторы фич
+	FeatureIds *[]int `json:"feature_ids,omitempty"`
+
+	// Items Пары фича-тэг
+	Items *[]struct {
+		FeatureId int `json:"feature_id"`
+		TagId     int `json:"tag_id"`
+	} `json:"items,omitempty"`
+
+	// TagId Тэг пользователя для фич из feature_ids
+	TagId *int `json:"tag_id,omitempty"`
+
+	// UseLastRevision Получать актуальную информацию
+	UseLastRevision *bool `json:"use_last_revision,omitempty"`
+}
+
+// PostUserBannerBatchParams defines parameters for PostUserBannerBatch.
+type PostUserBannerBatchParams struct {
+	// Token Токен пользователя
+	Token *string `json:"token,omitempty"`
+}
+
+// GetUserBannerStreamParams defines parameters for GetUserBannerStream.
+type GetUserBannerStreamParams struct {
+	TagId     int `form:"tag_id" json:"tag_id"`
+	FeatureId int `form:"feature_id" json:"feature_id"`
+
+	// Token Токен пользователя
+	Token *string `json:"token,omitempty"`
+
+	// LastEventID Идентификатор последнего полученного события
+	LastEventID *string `json:"Last-Event-ID,omitempty"`
+}
+
+// GetWebhooksParams defines parameters for GetWebhooks.
+type GetWebhooksParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// PostWebhooksJSONBody defines parameters for PostWebhooks.
+type PostWebhooksJSONBody struct {
+	// Events События: created, updated, deleted и activated - баннер включен изменением (такое изменение порождает и updated)
+	Events []PostWebhooksJSONBodyEvents `json:"events"`
+
+	// FeatureIds Фичи, баннеры которых отслеживаются. По умолчанию все
+	FeatureIds *[]int `json:"feature_ids,omitempty"`
+
+	// Secret Ключ подписи. По умолчанию генерируется
+	Secret *string `json:"secret,omitempty"`
+	Url    string  `json:"url"`
+}
+
+// PostWebhooksParams defines parameters for PostWebhooks.
+type PostWebhooksParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// PostWebhooksJSONBodyEvents defines parameters for PostWebhooks.
+type PostWebhooksJSONBodyEvents string
+
+// DeleteWebhooksIdParams defines parameters for DeleteWebhooksId.
+type DeleteWebhooksIdParams struct {
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
 }
 
+// GetWebhooksIdDeliveriesParams defines parameters for GetWebhooksIdDeliveries.
+type GetWebhooksIdDeliveriesParams struct {
+	Status *GetWebhooksIdDeliveriesParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	Limit  *int                                 `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int                                 `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Token Токен админа
+	Token *string `json:"token,omitempty"`
+}
+
+// GetWebhooksIdDeliveriesParamsStatus defines parameters for GetWebhooksIdDeliveries.
+type GetWebhooksIdDeliveriesParamsStatus string
+
+// PutAdminLogLevelJSONRequestBody defines body for PutAdminLogLevel for application/json ContentType.
+type PutAdminLogLevelJSONRequestBody = LogLevel
+
 // PostAuthJSONRequestBody defines body for PostAuth for application/json ContentType.
 type PostAuthJSONRequestBody PostAuthJSONBody
 
@@ -114,5 +572,14 @@ type PostBannerJSONRequestBody PostBannerJSONBody
 // PatchBannerIdJSONRequestBody defines body for PatchBannerId for application/json ContentType.
 type PatchBannerIdJSONRequestBody PatchBannerIdJSONBody
 
+// PostEventsClickJSONRequestBody defines body for PostEventsClick for application/json ContentType.
+type PostEventsClickJSONRequestBody PostEventsClickJSONBody
+
 // PostUserJSONRequestBody defines body for PostUser for application/json ContentType.
 type PostUserJSONRequestBody PostUserJSONBody
+
+// PostUserBannerBatchJSONRequestBody defines body for PostUserBannerBatch for application/json ContentType.
+type PostUserBannerBatchJSONRequestBody PostUserBannerBatchJSONBody
+
+// PostWebhooksJSONRequestBody defines body for PostWebhooks for application/json ContentType.
+type PostWebhooksJSONRequestBody PostWebhooksJSONBody
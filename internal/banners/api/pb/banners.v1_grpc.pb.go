@@ -0,0 +1,586 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: banners.v1.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	BannersService_GetUserBanner_FullMethodName      = "/banners.v1.BannersService/GetUserBanner"
+	BannersService_GetUserBannerBatch_FullMethodName = "/banners.v1.BannersService/GetUserBannerBatch"
+	BannersService_ListBanners_FullMethodName        = "/banners.v1.BannersService/ListBanners"
+	BannersService_ExportBanners_FullMethodName      = "/banners.v1.BannersService/ExportBanners"
+	BannersService_CreateBanner_FullMethodName       = "/banners.v1.BannersService/CreateBanner"
+	BannersService_UpdateBanner_FullMethodName       = "/banners.v1.BannersService/UpdateBanner"
+	BannersService_DeleteBanner_FullMethodName       = "/banners.v1.BannersService/DeleteBanner"
+	BannersService_WatchBanners_FullMethodName       = "/banners.v1.BannersService/WatchBanners"
+)
+
+// BannersServiceClient is the client API for BannersService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Операции повторяют REST API (api/banners.v1.yaml). Токен передается
+// в метаданных запроса под ключом token.
+type BannersServiceClient interface {
+	// Получение баннера для пользователя (GET /user_banner).
+	GetUserBanner(ctx context.Context, in *GetUserBannerRequest, opts ...grpc.CallOption) (*GetUserBannerResponse, error)
+	// Получение баннеров пользователя для нескольких фич (POST /user_banner/batch).
+	GetUserBannerBatch(ctx context.Context, in *GetUserBannerBatchRequest, opts ...grpc.CallOption) (*GetUserBannerBatchResponse, error)
+	// Получение всех баннеров c фильтрацией (GET /banner).
+	ListBanners(ctx context.Context, in *ListBannersRequest, opts ...grpc.CallOption) (*ListBannersResponse, error)
+	// Выгрузка баннеров (GET /banner/export).
+	ExportBanners(ctx context.Context, in *ExportBannersRequest, opts ...grpc.CallOption) (BannersService_ExportBannersClient, error)
+	// Создание нового баннера (POST /banner).
+	CreateBanner(ctx context.Context, in *CreateBannerRequest, opts ...grpc.CallOption) (*CreateBannerResponse, error)
+	// Обновление содержимого баннера (PATCH /banner/{id}).
+	UpdateBanner(ctx context.Context, in *UpdateBannerRequest, opts ...grpc.CallOption) (*UpdateBannerResponse, error)
+	// Удаление баннера по идентификатору (DELETE /banner/{id}).
+	DeleteBanner(ctx context.Context, in *DeleteBannerRequest, opts ...grpc.CallOption) (*DeleteBannerResponse, error)
+	// Поток изменений баннеров. Только для админов.
+	WatchBanners(ctx context.Context, in *WatchBannersRequest, opts ...grpc.CallOption) (BannersService_WatchBannersClient, error)
+}
+
+type bannersServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBannersServiceClient(cc grpc.ClientConnInterface) BannersServiceClient {
+	return &bannersServiceClient{cc}
+}
+
+func (c *bannersServiceClient) GetUserBanner(ctx context.Context, in *GetUserBannerRequest, opts ...grpc.CallOption) (*GetUserBannerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserBannerResponse)
+	err := c.cc.Invoke(ctx, BannersService_GetUserBanner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannersServiceClient) GetUserBannerBatch(ctx context.Context, in *GetUserBannerBatchRequest, opts ...grpc.CallOption) (*GetUserBannerBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserBannerBatchResponse)
+	err := c.cc.Invoke(ctx, BannersService_GetUserBannerBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannersServiceClient) ListBanners(ctx context.Context, in *ListBannersRequest, opts ...grpc.CallOption) (*ListBannersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBannersResponse)
+	err := c.cc.Invoke(ctx, BannersService_ListBanners_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannersServiceClient) ExportBanners(ctx context.Context, in *ExportBannersRequest, opts ...grpc.CallOption) (BannersService_ExportBannersClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BannersService_ServiceDesc.Streams[0], BannersService_ExportBanners_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bannersServiceExportBannersClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BannersService_ExportBannersClient interface {
+	Recv() (*Banner, error)
+	grpc.ClientStream
+}
+
+type bannersServiceExportBannersClient struct {
+	grpc.ClientStream
+}
+
+func (x *bannersServiceExportBannersClient) Recv() (*Banner, error) {
+	m := new(Banner)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bannersServiceClient) CreateBanner(ctx context.Context, in *CreateBannerRequest, opts ...grpc.CallOption) (*CreateBannerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBannerResponse)
+	err := c.cc.Invoke(ctx, BannersService_CreateBanner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannersServiceClient) UpdateBanner(ctx context.Context, in *UpdateBannerRequest, opts ...grpc.CallOption) (*UpdateBannerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateBannerResponse)
+	err := c.cc.Invoke(ctx, BannersService_UpdateBanner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannersServiceClient) DeleteBanner(ctx context.Context, in *DeleteBannerRequest, opts ...grpc.CallOption) (*DeleteBannerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBannerResponse)
+	err := c.cc.Invoke(ctx, BannersService_DeleteBanner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannersServiceClient) WatchBanners(ctx context.Context, in *WatchBannersRequest, opts ...grpc.CallOption) (BannersService_WatchBannersClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BannersService_ServiceDesc.Streams[1], BannersService_WatchBanners_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bannersServiceWatchBannersClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BannersService_WatchBannersClient interface {
+	Recv() (*BannerEvent, error)
+	grpc.ClientStream
+}
+
+type bannersServiceWatchBannersClient struct {
+	grpc.ClientStream
+}
+
+func (x *bannersServiceWatchBannersClient) Recv() (*BannerEvent, error) {
+	m := new(BannerEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BannersServiceServer is the server API for BannersService service.
+// All implementations must embed UnimplementedBannersServiceServer
+// for forward compatibility
+//
+// Операции повторяют REST API (api/banners.v1.yaml). Токен передается
+// в метаданных запроса под ключом token.
+type BannersServiceServer interface {
+	// Получение баннера для пользователя (GET /user_banner).
+	GetUserBanner(context.Context, *GetUserBannerRequest) (*GetUserBannerResponse, error)
+	// Получение баннеров пользователя для нескольких фич (POST /user_banner/batch).
+	GetUserBannerBatch(context.Context, *GetUserBannerBatchRequest) (*GetUserBannerBatchResponse, error)
+	// Получение всех баннеров c фильтрацией (GET /banner).
+	ListBanners(context.Context, *ListBannersRequest) (*ListBannersResponse, error)
+	// Выгрузка баннеров (GET /banner/export).
+	ExportBanners(*ExportBannersRequest, BannersService_ExportBannersServer) error
+	// Создание нового баннера (POST /banner).
+	CreateBanner(context.Context, *CreateBannerRequest) (*CreateBannerResponse, error)
+	// Обновление содержимого баннера (PATCH /banner/{id}).
+	UpdateBanner(context.Context, *UpdateBannerRequest) (*UpdateBannerResponse, error)
+	// Удаление баннера по идентификатору (DELETE /banner/{id}).
+	DeleteBanner(context.Context, *DeleteBannerRequest) (*DeleteBannerResponse, error)
+	// Поток изменений баннеров. Только для админов.
+	WatchBanners(*WatchBannersRequest, BannersService_WatchBannersServer) error
+	mustEmbedUnimplementedBannersServiceServer()
+}
+
+// UnimplementedBannersServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBannersServiceServer struct {
+}
+
+func (UnimplementedBannersServiceServer) GetUserBanner(context.Context, *GetUserBannerRequest) (*GetUserBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserBanner not implemented")
+}
+func (UnimplementedBannersServiceServer) GetUserBannerBatch(context.Context, *GetUserBannerBatchRequest) (*GetUserBannerBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserBannerBatch not implemented")
+}
+func (UnimplementedBannersServiceServer) ListBanners(context.Context, *ListBannersRequest) (*ListBannersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBanners not implemented")
+}
+func (UnimplementedBannersServiceServer) ExportBanners(*ExportBannersRequest, BannersService_ExportBannersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportBanners not implemented")
+}
+func (UnimplementedBannersServiceServer) CreateBanner(context.Context, *CreateBannerRequest) (*CreateBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBanner not implemented")
+}
+func (UnimplementedBannersServiceServer) UpdateBanner(context.Context, *UpdateBannerRequest) (*UpdateBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBanner not implemented")
+}
+func (UnimplementedBannersServiceServer) DeleteBanner(context.Context, *DeleteBannerRequest) (*DeleteBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBanner not implemented")
+}
+func (UnimplementedBannersServiceServer) WatchBanners(*WatchBannersRequest, BannersService_WatchBannersServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBanners not implemented")
+}
+func (UnimplementedBannersServiceServer) mustEmbedUnimplementedBannersServiceServer() {}
+
+// UnsafeBannersServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BannersServiceServer will
+// result in compilation errors.
+type UnsafeBannersServiceServer interface {
+	mustEmbedUnimplementedBannersServiceServer()
+}
+
+func RegisterBannersServiceServer(s grpc.ServiceRegistrar, srv BannersServiceServer) {
+	s.RegisterService(&BannersService_ServiceDesc, srv)
+}
+
+func _BannersService_GetUserBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannersServiceServer).GetUserBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannersService_GetUserBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannersServiceServer).GetUserBanner(ctx, req.(*GetUserBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannersService_GetUserBannerBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserBannerBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannersServiceServer).GetUserBannerBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannersService_GetUserBannerBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannersServiceServer).GetUserBannerBatch(ctx, req.(*GetUserBannerBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannersService_ListBanners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBannersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannersServiceServer).ListBanners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannersService_ListBanners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannersServiceServer).ListBanners(ctx, req.(*ListBannersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannersService_ExportBanners_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportBannersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BannersServiceServer).ExportBanners(m, &bannersServiceExportBannersServer{ServerStream: stream})
+}
+
+type BannersService_ExportBannersServer interface {
+	Send(*Banner) error
+	grpc.ServerStream
+}
+
+type bannersServiceExportBannersServer struct {
+	grpc.ServerStream
+}
+
+func (x *bannersServiceExportBannersServer) Send(m *Banner) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BannersService_CreateBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannersServiceServer).CreateBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannersService_CreateBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannersServiceServer).CreateBanner(ctx, req.(*CreateBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannersService_UpdateBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannersServiceServer).UpdateBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannersService_UpdateBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannersServiceServer).UpdateBanner(ctx, req.(*UpdateBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannersService_DeleteBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannersServiceServer).DeleteBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannersService_DeleteBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannersServiceServer).DeleteBanner(ctx, req.(*DeleteBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannersService_WatchBanners_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBannersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BannersServiceServer).WatchBanners(m, &bannersServiceWatchBannersServer{ServerStream: stream})
+}
+
+type BannersService_WatchBannersServer interface {
+	Send(*BannerEvent) error
+	grpc.ServerStream
+}
+
+type bannersServiceWatchBannersServer struct {
+	grpc.ServerStream
+}
+
+func (x *bannersServiceWatchBannersServer) Send(m *BannerEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// BannersService_ServiceDesc is the grpc.ServiceDesc for BannersService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BannersService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "banners.v1.BannersService",
+	HandlerType: (*BannersServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUserBanner",
+			Handler:    _BannersService_GetUserBanner_Handler,
+		},
+		{
+			MethodName: "GetUserBannerBatch",
+			Handler:    _BannersService_GetUserBannerBatch_Handler,
+		},
+		{
+			MethodName: "ListBanners",
+			Handler:    _BannersService_ListBanners_Handler,
+		},
+		{
+			MethodName: "CreateBanner",
+			Handler:    _BannersService_CreateBanner_Handler,
+		},
+		{
+			MethodName: "UpdateBanner",
+			Handler:    _BannersService_UpdateBanner_Handler,
+		},
+		{
+			MethodName: "DeleteBanner",
+			Handler:    _BannersService_DeleteBanner_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportBanners",
+			Handler:       _BannersService_ExportBanners_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchBanners",
+			Handler:       _BannersService_WatchBanners_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "banners.v1.proto",
+}
+
+const (
+	AuthService_Auth_FullMethodName       = "/banners.v1.AuthService/Auth"
+	AuthService_CreateUser_FullMethodName = "/banners.v1.AuthService/CreateUser"
+)
+
+// AuthServiceClient is the client API for AuthService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuthServiceClient interface {
+	// Аутентификация пользователя (POST /auth).
+	Auth(ctx context.Context, in *AuthRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	// Создание пользователя (POST /user).
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
+}
+
+type authServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthServiceClient(cc grpc.ClientConnInterface) AuthServiceClient {
+	return &authServiceClient{cc}
+}
+
+func (c *authServiceClient) Auth(ctx context.Context, in *AuthRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_Auth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateUserResponse)
+	err := c.cc.Invoke(ctx, AuthService_CreateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+// All implementations must embed UnimplementedAuthServiceServer
+// for forward compatibility
+type AuthServiceServer interface {
+	// Аутентификация пользователя (POST /auth).
+	Auth(context.Context, *AuthRequest) (*AuthResponse, error)
+	// Создание пользователя (POST /user).
+	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+// UnimplementedAuthServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAuthServiceServer struct {
+}
+
+func (UnimplementedAuthServiceServer) Auth(context.Context, *AuthRequest) (*AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Auth not implemented")
+}
+func (UnimplementedAuthServiceServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
+
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// result in compilation errors.
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	s.RegisterService(&AuthService_ServiceDesc, srv)
+}
+
+func _AuthService_Auth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Auth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Auth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Auth(ctx, req.(*AuthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "banners.v1.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Auth",
+			Handler:    _AuthService_Auth_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _AuthService_CreateUser_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "banners.v1.proto",
+}
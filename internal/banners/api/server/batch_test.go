@@ -0,0 +1,61 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
+	"github.com/Leopold1975/banners_control/internal/banners/api/server"
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+	"github.com/Leopold1975/banners_control/internal/banners/services/bannerservice"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+type batchServiceMock struct {
+	server.BannerService
+
+	resp bannerservice.GetUserBannersResponse
+}
+
+func (bs batchServiceMock) GetUserBanners(context.Context,
+	bannerservice.GetUserBannersRequest,
+) (bannerservice.GetUserBannersResponse, error) {
+	return bs.resp, nil
+}
+
+func TestUserBannerBatchUnavailable(t *testing.T) {
+	lg, err := logger.New(config.Logger{Level: "info"}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	bs := batchServiceMock{resp: bannerservice.GetUserBannersResponse{ //nolint:exhaustruct
+		Banners: map[repo.FeatureTag][]models.Banner{
+			{FeatureID: 1, TagID: 1}: {{ID: 1, Content: map[string]interface{}{"title": "t"}}}, //nolint:exhaustruct
+		},
+		Stale:  true,
+		Failed: map[repo.FeatureTag]struct{}{{FeatureID: 2, TagID: 1}: {}},
+	}}
+
+	s := server.New(config.Server{}, bs, authServiceMock{}, nil, nil, nil, nil, nil, nil, nil, lg) //nolint:exhaustruct
+
+	token := "user_token"
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/v1/user_banner/batch",
+		strings.NewReader(`{"tag_id": 1, "feature_ids": [1, 2, 3]}`))
+
+	s.PostUserBannerBatch(w, r, oapi.PostUserBannerBatchParams{Token: &token})
+
+	// Баннер фичи 2 не получен из-за отказа БД, а у фичи 3 его действительно нет.
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotEmpty(t, w.Header().Get("Warning"))
+	require.JSONEq(t, `{"banners": {
+		"1": {"content": {"title": "t"}},
+		"2": {"error": "banner storage unavailable"},
+		"3": {"error": "banner not found"}
+	}}`, w.Body.String())
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+)
+
+// bannerETag - сильный ETag из идентификатора баннера и времени его изменения,
+// которое меняется при каждом обновлении. Время округляется до микросекунд так же,
+// как его хранит PostgreSQL, чтобы ETag баннера из кэша совпадал с ETag из БД.
+func bannerETag(b models.Banner) string {
+	updatedAt := b.UpdatedAt.Round(time.Microsecond).UnixMicro()
+
+	return `"` + strconv.FormatInt(b.ID, 10) + "-" + strconv.FormatInt(updatedAt, 36) + `"`
+}
+
+// etagMatch сравнивает ETag со значением If-None-Match по правилам слабого
+// сравнения из RFC 9110: префикс W/ не учитывается, * совпадает с любым ETag.
+func etagMatch(ifNoneMatch, etag string) bool {
+	for _, t := range strings.Split(ifNoneMatch, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || strings.TrimPrefix(t, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
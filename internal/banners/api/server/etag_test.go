@@ -0,0 +1,124 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
+	"github.com/Leopold1975/banners_control/internal/banners/api/server"
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/services/bannerservice"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+type bannerServiceMock struct {
+	server.BannerService
+
+	banner models.Banner
+}
+
+func (bs bannerServiceMock) GetBanner(context.Context, bannerservice.GetBannerRequest) (bannerservice.GetBannerResponse, error) {
+	return bannerservice.GetBannerResponse{Banners: []models.Banner{bs.banner}, MaxAge: time.Minute}, nil //nolint:exhaustruct
+}
+
+type authServiceMock struct {
+	server.AuthService
+}
+
+func (authServiceMock) Auth(string) (bool, error) {
+	return false, nil
+}
+
+func getUserBanner(t *testing.T, lg logger.Logger, banner models.Banner, ifNoneMatch *string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	s := server.New(config.Server{}, bannerServiceMock{banner: banner}, authServiceMock{}, //nolint:exhaustruct
+		nil, nil, nil, nil, nil, nil, nil, lg)
+
+	token := "user_token"
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/v1/user_banner?feature_id=1&tag_id=2", nil)
+
+	s.GetUserBanner(w, r, oapi.GetUserBannerParams{ //nolint:exhaustruct
+		FeatureId:   1,
+		TagId:       2,
+		Token:       &token,
+		IfNoneMatch: ifNoneMatch,
+	})
+
+	return w
+}
+
+func TestUserBannerETag(t *testing.T) {
+	lg, err := logger.New(config.Logger{Level: "info"}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	banner := models.Banner{ //nolint:exhaustruct
+		ID:        7,
+		FeatureID: 1,
+		Tags:      []int{2},
+		Active:    true,
+		Content:   map[string]interface{}{"title": "t"},
+		UpdatedAt: time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	get := func(ifNoneMatch *string) *httptest.ResponseRecorder {
+		return getUserBanner(t, lg, banner, ifNoneMatch)
+	}
+
+	w := get(nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotEmpty(t, w.Body.String())
+
+	etag := w.Header().Get("ETag")
+	require.Regexp(t, `^"7-[0-9a-z]+"$`, etag)
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		code        int
+	}{
+		{name: "strong", ifNoneMatch: etag, code: http.StatusNotModified},
+		{name: "weak", ifNoneMatch: "W/" + etag, code: http.StatusNotModified},
+		{name: "list", ifNoneMatch: `"1-abc", W/"2-def" ,` + etag, code: http.StatusNotModified},
+		{name: "any", ifNoneMatch: "*", code: http.StatusNotModified},
+		{name: "other version", ifNoneMatch: `"7-abc"`, code: http.StatusOK},
+		{name: "unquoted", ifNoneMatch: etag[1 : len(etag)-1], code: http.StatusOK},
+		{name: "weak list without match", ifNoneMatch: `W/"7-abc", "8-def"`, code: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := get(&tt.ifNoneMatch)
+			require.Equal(t, tt.code, w.Code)
+			require.Equal(t, etag, w.Header().Get("ETag"))
+			require.Equal(t, "7", w.Header().Get("X-Banner-Id"))
+
+			if tt.code == http.StatusNotModified {
+				require.Empty(t, w.Body.String())
+			} else {
+				require.JSONEq(t, `{"title":"t"}`, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUserBannerETagRounding(t *testing.T) {
+	lg, err := logger.New(config.Logger{Level: "info"}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	updatedAt := time.Date(2024, 4, 1, 12, 0, 0, 1500, time.UTC)
+
+	// Баннер из кэша хранит время с наносекундами, а PostgreSQL округляет его
+	// до микросекунд: ETag обеих версий должен совпадать.
+	cached := models.Banner{ID: 7, Active: true, UpdatedAt: updatedAt}                         //nolint:exhaustruct
+	stored := models.Banner{ID: 7, Active: true, UpdatedAt: updatedAt.Round(time.Microsecond)} //nolint:exhaustruct
+
+	require.Equal(t, getUserBanner(t, lg, stored, nil).Header().Get("ETag"),
+		getUserBanner(t, lg, cached, nil).Header().Get("ETag"))
+}
@@ -1,48 +1,145 @@
 package server
 
 import (
+	"context"
 	"net/http"
-	"net/http/httptest"
 	"time"
 
 	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func loggingMiddleware(logg logger.Logger) func(next http.Handler) http.Handler {
+const (
+	tracerName      = "github.com/Leopold1975/banners_control/internal/banners/api/server"
+	requestIDHeader = "X-Request-ID"
+)
+
+// loggingMiddleware кладет в контекст логгер запроса с полями request_id, route
+// и user и пишет структурированную запись о каждом запросе. Ответ не буферизуется:
+// в лог попадает только начало тела ответа с ошибкой.
+func loggingMiddleware(logg logger.Logger, as AuthService) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
-			rr := httptest.NewRecorder()
-
-			defer func() {
-				latency := time.Since(start).String()
-
-				logg.Infof("METHOD %s URI %s %s	STATUS %d Latency %s Client IP %s User Agent %s",
-					r.Method,
-					r.Proto,
-					r.URL.RequestURI(),
-					rr.Code,
-					latency,
-					r.RemoteAddr,
-					r.UserAgent(),
-				)
-			}()
+			rr := newResponseRecorder(w, maxErrorBodySize)
 
-			next.ServeHTTP(rr, r)
+			p := principal(as, r)
+			l := logg.With(
+				"request_id", requestIDFromContext(r.Context()),
+				"route", chi.RouteContext(r.Context()).RoutePattern(),
+				"user", p,
+			)
 
-			for k, v := range rr.Header() {
-				w.Header()[k] = v
+			ctx := context.WithValue(logger.WithContext(r.Context(), l), principalKey{}, p)
+
+			next.ServeHTTP(rr, r.WithContext(ctx))
+
+			fields := []interface{}{
+				"method", r.Method,
+				"uri", r.URL.RequestURI(),
+				"proto", r.Proto,
+				"status", rr.Status(),
+				"size", rr.bytes,
+				"latency", time.Since(start).String(),
+				"client_ip", r.RemoteAddr,
+				"user_agent", r.UserAgent(),
 			}
 
-			w.WriteHeader(rr.Code)
+			if rr.body.Len() != 0 {
+				fields = append(fields, "error", rr.body.String())
+			}
 
-			if rr.Code >= 400 && rr.Body.Len() != 0 {
-				logg.Errorf("error: %s", rr.Body)
+			if rr.Status() >= http.StatusInternalServerError {
+				l.Errorw("request", fields...)
+
+				return
 			}
 
-			_, err := rr.Body.WriteTo(w)
-			if err != nil {
-				logg.Errorf("middleware write error: %w", err)
+			l.Infow("request", fields...)
+		})
+	}
+}
+
+const (
+	anonymousPrincipal = "anonymous"
+	invalidPrincipal   = "invalid token"
+)
+
+type principalKey struct{}
+
+// principal определяет, от чьего имени выполнен запрос, по токену из заголовка.
+func principal(as AuthService, r *http.Request) string {
+	token := r.Header.Get("token")
+	if token == "" {
+		return anonymousPrincipal
+	}
+
+	p, err := as.Principal(token)
+	if err != nil {
+		return invalidPrincipal
+	}
+
+	return p
+}
+
+func principalFromContext(ctx context.Context) string {
+	p, _ := ctx.Value(principalKey{}).(string)
+
+	return p
+}
+
+func metricsMiddleware(m Metrics) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rr := newResponseRecorder(w, 0)
+
+			next.ServeHTTP(rr, r)
+
+			// Шаблон маршрута вместо URI, чтобы идентификаторы баннеров
+			// не раздували число временных рядов.
+			route := chi.RouteContext(r.Context()).RoutePattern()
+
+			m.ObserveRequest(r.Method, route, rr.Status(), time.Since(start))
+		})
+	}
+}
+
+// tracingMiddleware начинает серверный спан запроса. Если клиент передал
+// заголовок traceparent, спан становится продолжением его трассы.
+func tracingMiddleware() func(next http.Handler) http.Handler {
+	tracer := otel.Tracer(tracerName)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			route := chi.RouteContext(r.Context()).RoutePattern()
+
+			ctx, span := tracer.Start(ctx, r.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(r.Method),
+					semconv.HTTPRoute(route),
+					semconv.URLPath(r.URL.Path),
+					semconv.ClientAddress(r.RemoteAddr),
+					semconv.UserAgentOriginal(r.UserAgent()),
+				),
+			)
+			defer span.End()
+
+			rr := newResponseRecorder(w, 0)
+
+			next.ServeHTTP(rr, r.WithContext(ctx))
+
+			span.SetAttributes(semconv.HTTPResponseStatusCode(rr.Status()), semconv.HTTPResponseBodySize(rr.bytes))
+
+			if rr.Status() >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(rr.Status()))
 			}
 		})
 	}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/ratelimit"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+// rateLimitMiddleware ограничивает частоту запросов по пользователю из токена,
+// по самому токену, если он не прошел проверку, или по IP-адресу клиента.
+// Если хранилище счетчиков недоступно, запрос пропускается.
+func rateLimitMiddleware(rl RateLimiter) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if rl == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			class := routeClass(r.Method, chi.RouteContext(r.Context()).RoutePattern())
+			if class == "" {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			res, err := rl.Allow(r.Context(), class, rateLimitKey(r))
+			if err != nil {
+				logger.FromContext(r.Context()).Warnf("rate limit error: %s", err.Error())
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			if res.Limit != 0 {
+				w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+				w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+				w.Header().Set("RateLimit-Reset", ceilSeconds(res.Reset))
+			}
+
+			if !res.Allowed {
+				w.Header().Set("Retry-After", ceilSeconds(res.RetryAfter))
+				w.Header().Set("Content-Type", "application/json")
+				handleError(w, errRateLimited, http.StatusTooManyRequests)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// routeClass относит маршрут к классу с отдельным бюджетом. Проверки состояния
+// не ограничиваются, чтобы оркестратор не счел экземпляр неисправным.
+func routeClass(method, route string) string {
+	route = strings.TrimPrefix(route, baseURL)
+
+	switch {
+	case route == "/livez" || route == "/readyz" || route == "/healthz":
+		return ""
+	case strings.HasPrefix(route, "/user_banner") || route == "/events/click":
+		return ratelimit.ClassUser
+	case isAdminRoute(route) && method == http.MethodGet:
+		return ratelimit.ClassAdminRead
+	case isAdminRoute(route):
+		return ratelimit.ClassAdminWrite
+	default:
+		return ratelimit.ClassDefault
+	}
+}
+
+func rateLimitKey(r *http.Request) string {
+	token := r.Header.Get("token")
+
+	switch p := principalFromContext(r.Context()); {
+	case token == "" || p == "":
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+
+		return "ip:" + host
+	case p == invalidPrincipal:
+		// Сам токен в хранилище счетчиков не попадает.
+		sum := sha256.Sum256([]byte(token))
+
+		return "key:" + hex.EncodeToString(sum[:8])
+	default:
+		return "user:" + p
+	}
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+func isAdminRoute(route string) bool {
+	return strings.HasPrefix(route, "/banner") || strings.HasPrefix(route, "/webhooks")
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// maxErrorBodySize ограничивает часть тела ответа с ошибкой, которая попадает в лог.
+const maxErrorBodySize = 4 << 10
+
+var errHijackNotSupported = errors.New("response writer does not support hijacking")
+
+// responseRecorder передает ответ клиенту без буферизации и запоминает код ответа
+// и число записанных байт. Если captureLimit > 0, начало тела ответов с кодом >= 400
+// сохраняется для лога.
+type responseRecorder struct {
+	http.ResponseWriter
+	status       int
+	bytes        int
+	captureLimit int
+	body         bytes.Buffer
+}
+
+func newResponseRecorder(w http.ResponseWriter, captureLimit int) *responseRecorder {
+	return &responseRecorder{ResponseWriter: w, captureLimit: captureLimit} //nolint:exhaustruct
+}
+
+// WriteHeader передает дальше только первый код ответа: обработчики могут
+// вызвать его повторно после записи тела.
+func (rr *responseRecorder) WriteHeader(code int) {
+	if rr.status != 0 {
+		return
+	}
+
+	rr.status = code
+	rr.ResponseWriter.WriteHeader(code)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	if rr.status == 0 {
+		rr.WriteHeader(http.StatusOK)
+	}
+
+	if rr.status >= http.StatusBadRequest && rr.body.Len() < rr.captureLimit {
+		rr.body.Write(b[:min(len(b), rr.captureLimit-rr.body.Len())])
+	}
+
+	n, err := rr.ResponseWriter.Write(b)
+	rr.bytes += n
+
+	return n, err //nolint:wrapcheck
+}
+
+func (rr *responseRecorder) Flush() {
+	if rr.status == 0 {
+		rr.WriteHeader(http.StatusOK)
+	}
+
+	if f, ok := rr.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (rr *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := rr.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errHijackNotSupported
+	}
+
+	conn, rw, err := h.Hijack()
+	if err != nil {
+		return nil, nil, fmt.Errorf("hijack error: %w", err)
+	}
+
+	// После перехвата соединения код ответа пишет сам обработчик.
+	rr.status = http.StatusSwitchingProtocols
+
+	return conn, rw, nil
+}
+
+// Unwrap позволяет http.ResponseController добраться до исходного ResponseWriter.
+func (rr *responseRecorder) Unwrap() http.ResponseWriter {
+	return rr.ResponseWriter
+}
+
+// Status возвращает код ответа; если обработчик ничего не записал, это 200.
+func (rr *responseRecorder) Status() int {
+	if rr.status == 0 {
+		return http.StatusOK
+	}
+
+	return rr.status
+}
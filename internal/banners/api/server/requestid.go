@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// maxRequestIDLength ограничивает длину идентификатора, принятого от клиента.
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// requestIDMiddleware берет идентификатор запроса из заголовка X-Request-ID
+// или создает новый, кладет его в контекст и возвращает клиенту в том же заголовке.
+func requestIDMiddleware() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(requestIDHeader)
+			if !validRequestID(id) {
+				id = uuid.NewString()
+			}
+
+			w.Header().Set(requestIDHeader, id)
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+		})
+	}
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+
+	return id
+}
+
+// validRequestID отбрасывает пустые, слишком длинные и содержащие
+// непечатные символы идентификаторы, чтобы они не портили логи.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := range len(id) {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
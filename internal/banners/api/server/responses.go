@@ -1,5 +1,7 @@
 package server
 
+import "time"
+
 type CreateBannerResponse struct {
 	BannerID int `json:"banner_id"` //nolint:tagliatelle
 }
@@ -8,6 +10,13 @@ type GetUserBannerResponse struct {
 	Banner map[string]interface{}
 }
 
+// StreamBanner - данные события GET /user_banner/stream. Content пуст
+// для удаленного баннера.
+type StreamBanner struct {
+	BannerID int64                  `json:"banner_id"` //nolint:tagliatelle
+	Content  map[string]interface{} `json:"content,omitempty"`
+}
+
 type AuthUserResponse struct {
 	Token string `json:"token"`
 }
@@ -15,3 +24,60 @@ type AuthUserResponse struct {
 type CreateUserResponse struct {
 	Token string `json:"token"`
 }
+
+type HealthResponse struct {
+	Status   string `json:"status"`
+	NodeID   string `json:"node_id"` //nolint:tagliatelle
+	Leader   string `json:"leader"`
+	IsLeader bool   `json:"is_leader"` //nolint:tagliatelle
+}
+
+type ReadyResponse struct {
+	Ready    bool                     `json:"ready"`
+	Draining bool                     `json:"draining"`
+	Checks   map[string]CheckResponse `json:"checks"`
+}
+
+type CheckResponse struct {
+	Status    string  `json:"status"`
+	Error     string  `json:"error,omitempty"`
+	LatencyMs float64 `json:"latency_ms"`        //nolint:tagliatelle
+	Version   *int64  `json:"version,omitempty"` //nolint:tagliatelle
+	Warm      *bool   `json:"warm,omitempty"`
+}
+
+type BatchBannersResponse struct {
+	Banners map[string]BatchBanner `json:"banners"`
+}
+
+type BatchBanner struct {
+	Content map[string]interface{} `json:"content,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+type ImportResponse struct {
+	Total   int                   `json:"total"`
+	Created int                   `json:"created"`
+	Updated int                   `json:"updated"`
+	Failed  int                   `json:"failed"`
+	DryRun  bool                  `json:"dry_run"` //nolint:tagliatelle
+	Errors  []ImportErrorResponse `json:"errors,omitempty"`
+}
+
+type ImportErrorResponse struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+type DeliveryResponse struct {
+	ID            int64      `json:"delivery_id"` //nolint:tagliatelle
+	Event         string     `json:"event"`
+	BannerID      int64      `json:"banner_id"` //nolint:tagliatelle
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"` //nolint:tagliatelle
+	ResponseCode  int        `json:"response_code,omitempty"`   //nolint:tagliatelle
+	LastError     string     `json:"last_error,omitempty"`      //nolint:tagliatelle
+	CreatedAt     time.Time  `json:"created_at"`                //nolint:tagliatelle
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`    //nolint:tagliatelle
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
@@ -14,35 +16,107 @@ import (
 	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
 	"github.com/Leopold1975/banners_control/internal/banners/services/authservice"
 	"github.com/Leopold1975/banners_control/internal/banners/services/bannerservice"
+	"github.com/Leopold1975/banners_control/internal/banners/services/healthservice"
+	"github.com/Leopold1975/banners_control/internal/banners/services/statsservice"
+	"github.com/Leopold1975/banners_control/internal/banners/services/webhookservice"
 	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/internal/pkg/ratelimit"
 	"github.com/Leopold1975/banners_control/pkg/logger"
+	"go.opentelemetry.io/otel"
 )
 
+const (
+	baseURL = "/v1"
+	// maxBatchSize ограничивает число пар фича-тэг в одном пакетном запросе.
+	maxBatchSize = 100
+)
+
+var errRateLimited = errors.New("rate limit exceeded")
+
 type Server struct {
-	serv          *http.Server
-	bannerService BannerService
-	authService   AuthService
+	serv           *http.Server
+	bannerService  BannerService
+	authService    AuthService
+	elector        LeaderElector
+	healthService  HealthService
+	logLevel       LogLevel
+	drainDelay     time.Duration
+	changes        Changes
+	streams        *streams
+	webhookService WebhookService
+	statsService   StatsService
 }
 
 type BannerService interface {
-	GetBanner(context.Context, bannerservice.GetBannerRequest) ([]models.Banner, error)
+	GetBanner(context.Context, bannerservice.GetBannerRequest) (bannerservice.GetBannerResponse, error)
+	GetUserBanners(context.Context, bannerservice.GetUserBannersRequest) (bannerservice.GetUserBannersResponse, error)
 	CreateBanner(context.Context, models.Banner) (int, error)
 	DeleteBanner(context.Context, int) error
 	UpdateBanner(context.Context, models.Banner) error
+	ExportBanners(context.Context, bannerservice.GetBannerRequest, func(models.Banner) error) error
+	ImportBanners(context.Context, bannerservice.ImportRequest) (bannerservice.ImportResponse, error)
 	Shutdown(context.Context) error
 }
 
+type WebhookService interface {
+	CreateWebhook(context.Context, webhookservice.CreateWebhookRequest) (models.Webhook, error)
+	GetWebhooks(context.Context) ([]models.Webhook, error)
+	DeleteWebhook(context.Context, int) error
+	GetDeliveries(context.Context, webhookservice.GetDeliveriesRequest) ([]models.WebhookDelivery, error)
+}
+
+type StatsService interface {
+	Impression(bannerID int64, tagID int)
+	Click(ctx context.Context, bannerID int64, tagID int) error
+	GetStats(context.Context, statsservice.GetStatsRequest) (models.BannerStats, error)
+}
+
 type AuthService interface {
 	CreateUser(context.Context, authservice.CreateUserRequest) (string, error)
 	Auth(string) (bool, error)
 	Login(context.Context, string, string) (string, error)
+	Principal(string) (string, error)
+}
+
+type Metrics interface {
+	ObserveRequest(method, route string, code int, duration time.Duration)
+}
+
+type HealthService interface {
+	Ready(context.Context) healthservice.ReadyResponse
+	Drain()
+}
+
+type RateLimiter interface {
+	Allow(ctx context.Context, class, key string) (ratelimit.Result, error)
+}
+
+// LogLevel позволяет менять уровень логирования без перезапуска.
+type LogLevel interface {
+	Level() string
+	SetLevel(string) error
 }
 
-func New(cfg config.Server, bs BannerService, authService AuthService, lg logger.Logger) *Server {
+type LeaderElector interface {
+	ID() string
+	IsLeader() bool
+	Leader() string
+}
+
+func New(cfg config.Server, bs BannerService, authService AuthService, elector LeaderElector,
+	hs HealthService, changes Changes, ws WebhookService, ss StatsService, rl RateLimiter, m Metrics, lg logger.Logger,
+) *Server {
 	var s Server
 	h := oapi.HandlerWithOptions(&s, oapi.ChiServerOptions{ //nolint:exhaustruct
-		BaseURL:     "/v1",
-		Middlewares: []oapi.MiddlewareFunc{loggingMiddleware(lg)},
+		BaseURL: "/v1",
+		// Последний middleware в списке выполняется первым.
+		Middlewares: []oapi.MiddlewareFunc{
+			rateLimitMiddleware(rl),
+			loggingMiddleware(lg, authService),
+			metricsMiddleware(m),
+			tracingMiddleware(),
+			requestIDMiddleware(),
+		},
 	})
 	serv := &http.Server{ //nolint:exhaustruct
 		Addr:         cfg.Addr,
@@ -54,6 +128,14 @@ func New(cfg config.Server, bs BannerService, authService AuthService, lg logger
 	s.serv = serv
 	s.bannerService = bs
 	s.authService = authService
+	s.elector = elector
+	s.healthService = hs
+	s.logLevel = lg
+	s.drainDelay = cfg.DrainDelay
+	s.changes = changes
+	s.streams = newStreams(cfg.Stream)
+	s.webhookService = ws
+	s.statsService = ss
 
 	return &s
 }
@@ -70,13 +152,8 @@ func (s Server) Start(ctx context.Context) error {
 
 	select {
 	case <-ctx.Done():
-		ctxS, cancel := context.WithTimeout(context.Background(), time.Second*5) //nolint:gomnd
-		defer cancel()
-
-		if err := s.Shutdown(ctxS); err != nil { //nolint:contextcheck
-			return fmt.Errorf("context error: %w server error %w", ctxS.Err(), err)
-		}
-
+		// Соединения закрывает Shutdown: его вызывает владелец сервера после
+		// остановки, чтобы ожидание drainDelay выполнялось один раз.
 		if !errors.Is(ctx.Err(), context.Canceled) {
 			return fmt.Errorf("context cancelled error: %w", ctx.Err())
 		}
@@ -87,7 +164,24 @@ func (s Server) Start(ctx context.Context) error {
 	}
 }
 
+// Shutdown сначала переводит /readyz в состояние отказа и ждет drainDelay,
+// чтобы балансировщик успел исключить экземпляр, и только затем закрывает соединения.
 func (s Server) Shutdown(ctx context.Context) error {
+	s.healthService.Drain()
+
+	if s.drainDelay > 0 {
+		t := time.NewTimer(s.drainDelay)
+
+		select {
+		case <-ctx.Done():
+			t.Stop()
+		case <-t.C:
+		}
+	}
+
+	// Потоки изменений сами не завершаются, клиенты переподключатся к другой реплике.
+	s.streams.close()
+
 	ctxS, cancel := context.WithTimeout(ctx, s.serv.IdleTimeout)
 	defer cancel()
 
@@ -122,37 +216,36 @@ func (s Server) GetBanner(w http.ResponseWriter, r *http.Request, params oapi.Ge
 		return
 	}
 
-	var req bannerservice.GetBannerRequest
-	if params.FeatureId == nil {
-		req.FeatureID = -1
-	} else {
-		req.FeatureID = *params.FeatureId
-	}
-
-	if params.TagId != nil {
-		req.Tags = []int{*params.TagId}
-	}
-
-	if params.Offset != nil {
-		req.Offset = *params.Offset
-	}
+	req, err := adminBannersRequest(params)
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
 
-	if params.Limit != nil {
-		req.Limit = *params.Limit
+		return
 	}
 
 	req.IsAdmin = isAdmin
 
-	banners, err := s.bannerService.GetBanner(r.Context(), req)
+	resp, err := s.bannerService.GetBanner(r.Context(), req)
 	if err != nil {
-		handleError(w, fmt.Errorf("get banner error: %w", err), http.StatusInternalServerError)
+		handleError(w, fmt.Errorf("get banner error: %w", err), errorCode(err))
 
 		return
 	}
 
+	if resp.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", resp.NextCursor)
+	}
+
+	if req.WithTotal {
+		w.Header().Set("X-Total-Count", strconv.Itoa(resp.Total))
+	}
+
+	_, span := otel.Tracer(tracerName).Start(r.Context(), "encode banners")
+	defer span.End()
+
 	enc := json.NewEncoder(w)
 
-	err = enc.Encode(banners)
+	err = enc.Encode(resp.Banners)
 	if err != nil {
 		handleError(w, fmt.Errorf("encode error: %w", err), http.StatusInternalServerError)
 
@@ -217,7 +310,7 @@ func (s Server) PostBanner(w http.ResponseWriter, r *http.Request, params oapi.P
 
 	id, err := s.bannerService.CreateBanner(r.Context(), bn)
 	if err != nil {
-		handleError(w, fmt.Errorf("create banner error: %w", err), http.StatusInternalServerError)
+		handleError(w, fmt.Errorf("create banner error: %w", err), errorCode(err))
 
 		return
 	}
@@ -268,7 +361,7 @@ func (s Server) DeleteBannerId(w http.ResponseWriter, r *http.Request, id int, /
 			return
 		}
 
-		handleError(w, fmt.Errorf("delete banner error: %w", err), http.StatusInternalServerError)
+		handleError(w, fmt.Errorf("delete banner error: %w", err), errorCode(err))
 
 		return
 	}
@@ -340,7 +433,7 @@ func (s Server) PatchBannerId(w http.ResponseWriter, r *http.Request, id int, //
 			return
 		}
 
-		handleError(w, fmt.Errorf("update banner error: %w", err), http.StatusInternalServerError)
+		handleError(w, fmt.Errorf("update banner error: %w", err), errorCode(err))
 
 		return
 	}
@@ -375,21 +468,61 @@ func (s Server) GetUserBanner(w http.ResponseWriter, r *http.Request, params oap
 		req.UseLastRevision = *params.UseLastRevision
 	}
 
-	banners, err := s.bannerService.GetBanner(r.Context(), req)
+	resp, err := s.bannerService.GetBanner(r.Context(), req)
 	if err != nil {
-		handleError(w, fmt.Errorf("get banner error: %w", err), http.StatusInternalServerError)
+		handleError(w, fmt.Errorf("get banner error: %w", err), errorCode(err))
 
 		return
 	}
 
-	if len(banners) == 0 {
+	if len(resp.Banners) == 0 {
 		w.WriteHeader(http.StatusNotFound)
 
 		return
 	}
 
-	i := rand.Intn(len(banners)) //nolint:gosec
-	content := banners[i].Content
+	if resp.Stale {
+		w.Header().Set("Warning", `110 - "Response is Stale"`)
+	}
+
+	if resp.Age != 0 {
+		w.Header().Set("Age", strconv.Itoa(int(resp.Age.Seconds())))
+	}
+
+	// Актуальные данные не должны оседать в кэшах клиента.
+	if req.UseLastRevision || isAdmin {
+		w.Header().Set("Cache-Control", "no-store")
+	} else {
+		w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(int(resp.MaxAge.Seconds())))
+	}
+
+	// Клиенту подходит любой из баннеров фичи и тэга, поэтому если его версия
+	// совпадает с одним из них, тело не передается.
+	if params.IfNoneMatch != nil {
+		for _, b := range resp.Banners {
+			if etagMatch(*params.IfNoneMatch, bannerETag(b)) {
+				// Показ учитывается намеренно: клиент показывает баннер из своего кэша.
+				s.impression(b.ID, params.TagId)
+
+				w.Header().Set("ETag", bannerETag(b))
+				w.Header().Set("X-Banner-Id", strconv.FormatInt(b.ID, 10))
+				w.WriteHeader(http.StatusNotModified)
+
+				return
+			}
+		}
+	}
+
+	i := rand.Intn(len(resp.Banners)) //nolint:gosec
+	content := resp.Banners[i].Content
+
+	w.Header().Set("ETag", bannerETag(resp.Banners[i]))
+	w.Header().Set("X-Banner-Id", strconv.FormatInt(resp.Banners[i].ID, 10))
+
+	s.impression(resp.Banners[i].ID, params.TagId)
+
+	_, span := otel.Tracer(tracerName).Start(r.Context(), "encode banner content")
+	defer span.End()
 
 	enc := json.NewEncoder(w)
 
@@ -403,6 +536,130 @@ func (s Server) GetUserBanner(w http.ResponseWriter, r *http.Request, params oap
 	w.WriteHeader(http.StatusOK)
 }
 
+// Получение баннеров пользователя для нескольких фич
+// (POST /user_banner/batch).
+func (s Server) PostUserBannerBatch(w http.ResponseWriter, r *http.Request, //nolint:cyclop
+	params oapi.PostUserBannerBatchParams,
+) {
+	w.Header().Add("Content-Type", "application/json")
+
+	if params.Token == nil {
+		handleError(w, fmt.Errorf("token required"), http.StatusUnauthorized) //nolint:perfsprint
+
+		return
+	}
+
+	isAdmin, err := s.authService.Auth(*params.Token)
+	if err != nil {
+		handleError(w, fmt.Errorf("authorization error: %w", err), http.StatusUnauthorized)
+
+		return
+	}
+
+	var b oapi.PostUserBannerBatchJSONBody
+
+	dec := json.NewDecoder(r.Body)
+
+	err = dec.Decode(&b)
+	if err != nil {
+		handleError(w, fmt.Errorf("decode error: %w", err), http.StatusBadRequest)
+
+		return
+	}
+
+	var req bannerservice.GetUserBannersRequest
+
+	if b.FeatureIds != nil {
+		if b.TagId == nil {
+			handleError(w, fmt.Errorf("tag_id required with feature_ids"), http.StatusBadRequest) //nolint:perfsprint
+
+			return
+		}
+
+		for _, f := range *b.FeatureIds {
+			req.Pairs = append(req.Pairs, repo.FeatureTag{FeatureID: f, TagID: *b.TagId})
+		}
+	}
+
+	if b.Items != nil {
+		for _, item := range *b.Items {
+			req.Pairs = append(req.Pairs, repo.FeatureTag{FeatureID: item.FeatureId, TagID: item.TagId})
+		}
+	}
+
+	if len(req.Pairs) == 0 || len(req.Pairs) > maxBatchSize {
+		handleError(w, fmt.Errorf("expected from 1 to %d features", maxBatchSize), http.StatusBadRequest)
+
+		return
+	}
+
+	req.IsAdmin = isAdmin
+
+	if b.UseLastRevision != nil {
+		req.UseLastRevision = *b.UseLastRevision
+	}
+
+	resp, err := s.bannerService.GetUserBanners(r.Context(), req)
+	if err != nil {
+		handleError(w, fmt.Errorf("get banners error: %w", err), errorCode(err))
+
+		return
+	}
+
+	if resp.Stale {
+		w.Header().Set("Warning", `110 - "Response is Stale"`)
+	}
+
+	if req.UseLastRevision || isAdmin {
+		w.Header().Set("Cache-Control", "no-store")
+	}
+
+	type shownBanner struct {
+		bannerID int64
+		tagID    int
+	}
+
+	batch := BatchBannersResponse{Banners: make(map[string]BatchBanner, len(req.Pairs))}
+	// Показ учитывается только для баннера, попавшего в ответ.
+	shown := make(map[string]shownBanner, len(req.Pairs))
+
+	for _, p := range req.Pairs {
+		key := strconv.Itoa(p.FeatureID)
+
+		if banners := resp.Banners[p]; len(banners) != 0 {
+			i := rand.Intn(len(banners)) //nolint:gosec
+			batch.Banners[key] = BatchBanner{Content: banners[i].Content, Error: ""}
+			shown[key] = shownBanner{bannerID: banners[i].ID, tagID: p.TagID}
+
+			continue
+		}
+
+		// Фича может встретиться в нескольких парах: достаточно одной найденной.
+		// Недоступность хранилища важнее отсутствия баннера: клиент может повторить запрос.
+		if _, failed := resp.Failed[p]; failed {
+			if prev, ok := batch.Banners[key]; !ok || prev.Content == nil {
+				batch.Banners[key] = BatchBanner{Content: nil, Error: bannerservice.ErrUnavailable.Error()}
+			}
+		} else if _, ok := batch.Banners[key]; !ok {
+			batch.Banners[key] = BatchBanner{Content: nil, Error: bannerservice.ErrNotFound.Error()}
+		}
+	}
+
+	bts, err := json.Marshal(batch)
+	if err != nil {
+		handleError(w, fmt.Errorf("encode error: %w", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	for _, b := range shown {
+		s.impression(b.bannerID, b.tagID)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(bts) //nolint:errcheck
+}
+
 // Аутентификация пользователя
 // (POST /auth).
 func (s Server) PostAuth(w http.ResponseWriter, r *http.Request) {
@@ -499,10 +756,294 @@ func (s Server) PostUser(w http.ResponseWriter, r *http.Request, params oapi.Pos
 	w.Write(bts) //nolint:errcheck
 }
 
+// Текущий уровень логирования
+// (GET /admin/log_level).
+func (s Server) GetAdminLogLevel(w http.ResponseWriter, _ *http.Request, params oapi.GetAdminLogLevelParams) {
+	w.Header().Add("Content-Type", "application/json")
+
+	if params.Token == nil {
+		handleError(w, fmt.Errorf("admin token required"), http.StatusUnauthorized) //nolint:perfsprint
+
+		return
+	}
+
+	isAdmin, err := s.authService.Auth(*params.Token)
+	if err != nil {
+		handleError(w, fmt.Errorf("authorization error: %w", err), http.StatusUnauthorized)
+
+		return
+	}
+
+	if !isAdmin {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	s.writeLogLevel(w)
+}
+
+// Изменение уровня логирования без перезапуска
+// (PUT /admin/log_level).
+func (s Server) PutAdminLogLevel(w http.ResponseWriter, r *http.Request, params oapi.PutAdminLogLevelParams) {
+	w.Header().Add("Content-Type", "application/json")
+
+	if params.Token == nil {
+		handleError(w, fmt.Errorf("admin token required"), http.StatusUnauthorized) //nolint:perfsprint
+
+		return
+	}
+
+	isAdmin, err := s.authService.Auth(*params.Token)
+	if err != nil {
+		handleError(w, fmt.Errorf("authorization error: %w", err), http.StatusUnauthorized)
+
+		return
+	}
+
+	if !isAdmin {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	var b oapi.PutAdminLogLevelJSONRequestBody
+
+	dec := json.NewDecoder(r.Body)
+
+	err = dec.Decode(&b)
+	if err != nil {
+		handleError(w, fmt.Errorf("decode error: %w", err), http.StatusBadRequest)
+
+		return
+	}
+
+	if err := s.logLevel.SetLevel(string(b.Level)); err != nil {
+		handleError(w, fmt.Errorf("set log level error: %w", err), http.StatusBadRequest)
+
+		return
+	}
+
+	logger.FromContext(r.Context()).Warnf("log level changed to %s", b.Level)
+
+	s.writeLogLevel(w)
+}
+
+func (s Server) writeLogLevel(w http.ResponseWriter) {
+	bts, err := json.Marshal(oapi.LogLevel{Level: oapi.LogLevelLevel(s.logLevel.Level())})
+	if err != nil {
+		handleError(w, fmt.Errorf("encode error: %w", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(bts) //nolint:errcheck
+}
+
+// Состояние экземпляра сервиса
+// (GET /healthz).
+func (s Server) GetHealthz(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+
+	resp := HealthResponse{
+		Status:   "ok",
+		NodeID:   s.elector.ID(),
+		Leader:   s.elector.Leader(),
+		IsLeader: s.elector.IsLeader(),
+	}
+
+	bts, err := json.Marshal(resp)
+	if err != nil {
+		handleError(w, fmt.Errorf("encode error: %w", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(bts) //nolint:errcheck
+}
+
+// Проверка работоспособности экземпляра
+// (GET /livez).
+func (s Server) GetLivez(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"status":"ok"}`)) //nolint:errcheck
+}
+
+// Проверка готовности экземпляра принимать трафик
+// (GET /readyz).
+func (s Server) GetReadyz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+
+	ready := s.healthService.Ready(r.Context())
+
+	resp := ReadyResponse{
+		Ready:    ready.Ready,
+		Draining: ready.Draining,
+		Checks:   make(map[string]CheckResponse, len(ready.Checks)),
+	}
+
+	for name, c := range ready.Checks {
+		resp.Checks[name] = CheckResponse{
+			Status:    c.Status,
+			Error:     c.Error,
+			LatencyMs: float64(c.Latency.Microseconds()) / 1000, //nolint:gomnd
+			Version:   c.Version,
+			Warm:      c.Warm,
+		}
+	}
+
+	bts, err := json.Marshal(resp)
+	if err != nil {
+		handleError(w, fmt.Errorf("encode error: %w", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	if ready.Ready {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	w.Write(bts) //nolint:errcheck
+}
+
 func (s Server) GetDocs(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, "./docs/index.html")
 }
 
+// errorCode возвращает 503 для ошибок недоступности хранилища,
+// чтобы клиенты могли повторить запрос позже, и 400 для неверных фильтров.
+func errorCode(err error) int {
+	if errors.Is(err, bannerservice.ErrUnavailable) {
+		return http.StatusServiceUnavailable
+	}
+
+	if errors.Is(err, bannerservice.ErrInvalidFilter) || errors.Is(err, bannerservice.ErrInvalidCursor) ||
+		errors.Is(err, webhookservice.ErrInvalidWebhook) || errors.Is(err, statsservice.ErrInvalidEvent) ||
+		errors.Is(err, statsservice.ErrInvalidRange) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
+
+// adminBannersRequest переводит параметры GET /banner в запрос к сервису.
+// tag_id и feature_id объединяются со списками tag_ids и feature_ids.
+func adminBannersRequest(params oapi.GetBannerParams) (bannerservice.GetBannerRequest, error) { //nolint:cyclop,funlen
+	var (
+		req bannerservice.GetBannerRequest
+		err error
+	)
+
+	req.FeatureID = -1
+
+	if params.BannerIds != nil {
+		for _, id := range *params.BannerIds {
+			req.IDs = append(req.IDs, int64(id))
+		}
+	}
+
+	if params.FeatureIds != nil {
+		req.FeatureIDs = append(req.FeatureIDs, *params.FeatureIds...)
+	}
+
+	if params.FeatureId != nil {
+		if len(req.FeatureIDs) == 0 {
+			req.FeatureID = *params.FeatureId
+		} else {
+			req.FeatureIDs = append(req.FeatureIDs, *params.FeatureId)
+		}
+	}
+
+	if params.TagId != nil {
+		req.Tags = []int{*params.TagId}
+	}
+
+	if params.TagIds != nil {
+		req.Tags = append(req.Tags, *params.TagIds...)
+	}
+
+	if params.TagMatch != nil {
+		switch *params.TagMatch {
+		case oapi.GetBannerParamsTagMatchAny, oapi.GetBannerParamsTagMatchAll:
+			req.TagMatch = repo.TagMatch(*params.TagMatch)
+		default:
+			return req, fmt.Errorf("%w: unknown tag_match %q", bannerservice.ErrInvalidFilter, *params.TagMatch)
+		}
+	}
+
+	req.Active = params.IsActive
+
+	if params.CreatedFrom != nil {
+		req.CreatedFrom = *params.CreatedFrom
+	}
+
+	if params.CreatedTo != nil {
+		req.CreatedTo = *params.CreatedTo
+	}
+
+	if params.UpdatedFrom != nil {
+		req.UpdatedFrom = *params.UpdatedFrom
+	}
+
+	if params.UpdatedTo != nil {
+		req.UpdatedTo = *params.UpdatedTo
+	}
+
+	if params.Q != nil {
+		req.Search = strings.TrimSpace(*params.Q)
+	}
+
+	if params.Offset != nil {
+		req.Offset = *params.Offset
+	}
+
+	if params.Limit != nil {
+		req.Limit = *params.Limit
+	}
+
+	if params.Content != nil {
+		req.ContentEq, err = bannerservice.ParseContentFilter(*params.Content)
+		if err != nil {
+			return req, err
+		}
+	}
+
+	if params.ContentPath != nil {
+		req.ContentPath = *params.ContentPath
+	}
+
+	if params.Cursor != nil {
+		if params.Offset != nil {
+			return req, fmt.Errorf("offset and cursor are mutually exclusive") //nolint:perfsprint
+		}
+
+		req.Cursor = *params.Cursor
+	}
+
+	if params.Sort != nil {
+		req.Sort = repo.SortField(*params.Sort)
+	}
+
+	if params.Order != nil {
+		switch *params.Order {
+		case oapi.GetBannerParamsOrderAsc:
+		case oapi.GetBannerParamsOrderDesc:
+			req.Desc = true
+		default:
+			return req, fmt.Errorf("unknown order %q", *params.Order)
+		}
+	}
+
+	req.WithTotal = params.WithTotal != nil && *params.WithTotal
+
+	return req, nil
+}
+
 func handleError(w http.ResponseWriter, err error, code int) {
 	w.WriteHeader(code)
 
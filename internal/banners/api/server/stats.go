@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
+	"github.com/Leopold1975/banners_control/internal/banners/services/bannerservice"
+	"github.com/Leopold1975/banners_control/internal/banners/services/statsservice"
+)
+
+var errStatsDisabled = errors.New("stats are disabled")
+
+// Учет клика по баннеру
+// (POST /events/click).
+func (s Server) PostEventsClick(w http.ResponseWriter, r *http.Request, params oapi.PostEventsClickParams) {
+	w.Header().Add("Content-Type", "application/json")
+
+	if params.Token == nil {
+		handleError(w, fmt.Errorf("token required"), http.StatusUnauthorized) //nolint:perfsprint
+
+		return
+	}
+
+	if _, err := s.authService.Auth(*params.Token); err != nil {
+		handleError(w, fmt.Errorf("authorization error: %w", err), http.StatusUnauthorized)
+
+		return
+	}
+
+	if !s.checkStats(w) {
+		return
+	}
+
+	var b oapi.PostEventsClickJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		handleError(w, fmt.Errorf("decode error: %w", err), http.StatusBadRequest)
+
+		return
+	}
+
+	if err := s.checkClick(r.Context(), b.BannerId, b.TagId); err != nil {
+		handleError(w, fmt.Errorf("click error: %w", err), errorCode(err))
+
+		return
+	}
+
+	if err := s.statsService.Click(r.Context(), b.BannerId, b.TagId); err != nil {
+		handleError(w, fmt.Errorf("click error: %w", err), errorCode(err))
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Статистика показов и кликов баннера
+// (GET /banner/{id}/stats).
+func (s Server) GetBannerIdStats(w http.ResponseWriter, r *http.Request, id int, //nolint:revive,stylecheck
+	params oapi.GetBannerIdStatsParams,
+) {
+	w.Header().Add("Content-Type", "application/json")
+
+	if !s.checkAdmin(w, params.Token) || !s.checkStats(w) {
+		return
+	}
+
+	req := statsservice.GetStatsRequest{ //nolint:exhaustruct
+		BannerID: int64(id),
+	}
+
+	if params.From != nil {
+		req.From = *params.From
+	}
+
+	if params.To != nil {
+		req.To = *params.To
+	}
+
+	stats, err := s.statsService.GetStats(r.Context(), req)
+	if err != nil {
+		handleError(w, fmt.Errorf("get stats error: %w", err), errorCode(err))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func (s Server) checkStats(w http.ResponseWriter) bool {
+	if s.statsService == nil {
+		handleError(w, errStatsDisabled, http.StatusNotFound)
+
+		return false
+	}
+
+	return true
+}
+
+// checkClick проверяет, что пользователь мог получить баннер с этим тэгом:
+// баннер существует, активен и помечен тэгом. Иначе клики можно было бы
+// начислять любому баннеру.
+func (s Server) checkClick(ctx context.Context, bannerID int64, tagID int) error {
+	resp, err := s.bannerService.GetBanner(ctx, bannerservice.GetBannerRequest{ //nolint:exhaustruct
+		IDs:             []int64{bannerID},
+		FeatureID:       -1,
+		Tags:            []int{tagID},
+		Limit:           1,
+		UseLastRevision: true,
+	})
+	if err != nil {
+		return fmt.Errorf("get banner error: %w", err)
+	}
+
+	if len(resp.Banners) == 0 {
+		return fmt.Errorf("%w: banner %d not found, inactive or without tag %d",
+			statsservice.ErrInvalidEvent, bannerID, tagID)
+	}
+
+	return nil
+}
+
+// impression учитывает показ баннера, если статистика включена.
+func (s Server) impression(bannerID int64, tagID int) {
+	if s.statsService != nil {
+		s.statsService.Impression(bannerID, tagID)
+	}
+}
@@ -0,0 +1,155 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
+	"github.com/Leopold1975/banners_control/internal/banners/api/server"
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+	"github.com/Leopold1975/banners_control/internal/banners/services/bannerservice"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsDisabled(t *testing.T) {
+	lg, err := logger.New(config.Logger{Level: "info"}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	banner := models.Banner{ //nolint:exhaustruct
+		ID:        7,
+		FeatureID: 1,
+		Tags:      []int{2},
+		Active:    true,
+		Content:   map[string]interface{}{"title": "t"},
+	}
+
+	s := server.New(config.Server{}, bannerServiceMock{banner: banner}, adminAuthMock{}, //nolint:exhaustruct
+		nil, nil, nil, nil, nil, nil, nil, lg)
+
+	token := "admin_token"
+
+	// Показ не учитывается, баннер отдается как обычно
+	w := httptest.NewRecorder()
+	s.GetUserBanner(w, httptest.NewRequest(http.MethodGet, "/v1/user_banner?feature_id=1&tag_id=2", nil),
+		oapi.GetUserBannerParams{FeatureId: 1, TagId: 2, Token: &token}) //nolint:exhaustruct
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "7", w.Header().Get("X-Banner-Id"))
+
+	w = httptest.NewRecorder()
+	s.PostEventsClick(w, httptest.NewRequest(http.MethodPost, "/v1/events/click",
+		strings.NewReader(`{"banner_id": 7, "tag_id": 2}`)), oapi.PostEventsClickParams{Token: &token})
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.JSONEq(t, `{"error":"stats are disabled"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	s.GetBannerIdStats(w, httptest.NewRequest(http.MethodGet, "/v1/banner/7/stats", nil), 7,
+		oapi.GetBannerIdStatsParams{Token: &token}) //nolint:exhaustruct
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.JSONEq(t, `{"error":"stats are disabled"}`, w.Body.String())
+}
+
+type statsMock struct {
+	server.StatsService
+
+	impressions []int64
+	clicks      []int64
+}
+
+func (sm *statsMock) Impression(bannerID int64, _ int) {
+	sm.impressions = append(sm.impressions, bannerID)
+}
+
+func (sm *statsMock) Click(_ context.Context, bannerID int64, _ int) error {
+	sm.clicks = append(sm.clicks, bannerID)
+
+	return nil
+}
+
+// clickBannerServiceMock отбирает баннеры по идентификатору и тэгу, как хранилище
+// для пользователя: выключенные баннеры не возвращаются.
+type clickBannerServiceMock struct {
+	server.BannerService
+
+	banners []models.Banner
+}
+
+func (bs clickBannerServiceMock) GetBanner(_ context.Context,
+	req bannerservice.GetBannerRequest,
+) (bannerservice.GetBannerResponse, error) {
+	var resp bannerservice.GetBannerResponse
+
+	for _, b := range bs.banners {
+		if b.Active && slices.Contains(req.IDs, b.ID) && slices.Contains(b.Tags, req.Tags[0]) {
+			resp.Banners = append(resp.Banners, b)
+		}
+	}
+
+	return resp, nil
+}
+
+func TestEventsClick(t *testing.T) {
+	lg, err := logger.New(config.Logger{Level: "info"}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	bs := clickBannerServiceMock{banners: []models.Banner{
+		{ID: 7, Tags: []int{2}, Active: true},  //nolint:exhaustruct
+		{ID: 8, Tags: []int{2}, Active: false}, //nolint:exhaustruct
+	}}
+	stats := &statsMock{} //nolint:exhaustruct
+
+	s := server.New(config.Server{}, bs, authServiceMock{}, nil, nil, nil, nil, stats, nil, nil, lg) //nolint:exhaustruct
+
+	token := "user_token"
+
+	tests := []struct {
+		name string
+		body string
+		code int
+	}{
+		{name: "active banner with tag", body: `{"banner_id": 7, "tag_id": 2}`, code: http.StatusNoContent},
+		{name: "other tag", body: `{"banner_id": 7, "tag_id": 3}`, code: http.StatusBadRequest},
+		{name: "inactive banner", body: `{"banner_id": 8, "tag_id": 2}`, code: http.StatusBadRequest},
+		{name: "unknown banner", body: `{"banner_id": 9, "tag_id": 2}`, code: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.PostEventsClick(w, httptest.NewRequest(http.MethodPost, "/v1/events/click", strings.NewReader(tt.body)),
+				oapi.PostEventsClickParams{Token: &token})
+			require.Equal(t, tt.code, w.Code)
+		})
+	}
+
+	require.Equal(t, []int64{7}, stats.clicks)
+}
+
+func TestUserBannerBatchImpressions(t *testing.T) {
+	lg, err := logger.New(config.Logger{Level: "info"}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	bs := batchServiceMock{resp: bannerservice.GetUserBannersResponse{ //nolint:exhaustruct
+		Banners: map[repo.FeatureTag][]models.Banner{
+			{FeatureID: 1, TagID: 1}: {{ID: 5, Content: map[string]interface{}{"title": "a"}}}, //nolint:exhaustruct
+			{FeatureID: 2, TagID: 1}: {{ID: 6, Content: map[string]interface{}{"title": "b"}}}, //nolint:exhaustruct
+		},
+	}}
+	stats := &statsMock{} //nolint:exhaustruct
+
+	s := server.New(config.Server{}, bs, authServiceMock{}, nil, nil, nil, nil, stats, nil, nil, lg) //nolint:exhaustruct
+
+	token := "user_token"
+	w := httptest.NewRecorder()
+
+	s.PostUserBannerBatch(w, httptest.NewRequest(http.MethodPost, "/v1/user_banner/batch",
+		strings.NewReader(`{"tag_id": 1, "feature_ids": [1, 2, 3]}`)), oapi.PostUserBannerBatchParams{Token: &token})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.ElementsMatch(t, []int64{5, 6}, stats.impressions)
+}
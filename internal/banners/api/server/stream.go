@@ -0,0 +1,331 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/services/bannerservice"
+	"github.com/Leopold1975/banners_control/internal/banners/services/changebus"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+)
+
+var (
+	errTooManyStreams     = errors.New("too many streams")
+	errTooManyUserStreams = errors.New("too many streams for user")
+)
+
+// События потока GET /user_banner/stream.
+const (
+	streamEventBanner  = "banner"
+	streamEventCreated = "created"
+	streamEventUpdated = "updated"
+	streamEventDeleted = "deleted"
+	streamEventError   = "error"
+)
+
+// Changes выдает подписки на изменения баннеров для GET /user_banner/stream.
+type Changes interface {
+	Subscribe(changebus.Filter) *changebus.Subscription
+	SubscribeAfter(context.Context, changebus.Filter, string) (*changebus.Subscription, []changebus.Event, bool)
+	EventID(uint64) string
+}
+
+// streams ограничивает число открытых потоков всего и на пользователя
+// и закрывает их при остановке сервера: http.Server.Shutdown не дожидается
+// бесконечных ответов.
+type streams struct {
+	cfg     config.Stream
+	mu      sync.Mutex
+	total   int
+	perUser map[string]int
+	done    chan struct{}
+	once    sync.Once
+}
+
+func newStreams(cfg config.Stream) *streams {
+	return &streams{ //nolint:exhaustruct
+		cfg:     cfg,
+		perUser: make(map[string]int),
+		done:    make(chan struct{}),
+	}
+}
+
+func (st *streams) acquire(key string) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.cfg.MaxConnections != 0 && st.total >= st.cfg.MaxConnections {
+		return errTooManyStreams
+	}
+
+	if st.cfg.MaxPerUser != 0 && st.perUser[key] >= st.cfg.MaxPerUser {
+		return errTooManyUserStreams
+	}
+
+	st.total++
+	st.perUser[key]++
+
+	return nil
+}
+
+func (st *streams) release(key string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.total--
+
+	if st.perUser[key]--; st.perUser[key] == 0 {
+		delete(st.perUser, key)
+	}
+}
+
+func (st *streams) close() {
+	st.once.Do(func() { close(st.done) })
+}
+
+// Поток изменений баннера пользователя
+// (GET /user_banner/stream).
+func (s Server) GetUserBannerStream(w http.ResponseWriter, r *http.Request, //nolint:cyclop,funlen
+	params oapi.GetUserBannerStreamParams,
+) {
+	if params.Token == nil {
+		w.Header().Add("Content-Type", "application/json")
+		handleError(w, fmt.Errorf("token required"), http.StatusUnauthorized) //nolint:perfsprint
+
+		return
+	}
+
+	isAdmin, err := s.authService.Auth(*params.Token)
+	if err != nil {
+		w.Header().Add("Content-Type", "application/json")
+		handleError(w, fmt.Errorf("authorization error: %w", err), http.StatusUnauthorized)
+
+		return
+	}
+
+	key := rateLimitKey(r)
+
+	if err := s.streams.acquire(key); err != nil {
+		code := http.StatusServiceUnavailable
+		if errors.Is(err, errTooManyUserStreams) {
+			code = http.StatusTooManyRequests
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.Header().Set("Retry-After", ceilSeconds(s.streams.cfg.Retry))
+		handleError(w, err, code)
+
+		return
+	}
+	defer s.streams.release(key)
+
+	filter := changebus.Filter{FeatureID: params.FeatureId, TagID: params.TagId}
+
+	var (
+		sub     *changebus.Subscription
+		missed  []changebus.Event
+		resumed bool
+	)
+
+	if params.LastEventID != nil {
+		sub, missed, resumed = s.changes.SubscribeAfter(r.Context(), filter, *params.LastEventID)
+	} else {
+		sub = s.changes.Subscribe(filter)
+	}
+	defer sub.Close()
+
+	sw := &sseWriter{
+		w:            w,
+		rc:           http.NewResponseController(w), //nolint:bodyclose
+		writeTimeout: s.serv.WriteTimeout,
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	// Отключает буферизацию ответа в nginx.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	sw.retry(s.streams.cfg.Retry)
+
+	if resumed {
+		for _, e := range missed {
+			if name, data, ok := streamEvent(e, filter, isAdmin); ok {
+				sw.event(s.changes.EventID(e.Seq), name, data)
+			}
+		}
+	} else {
+		// Подписка оформлена до чтения баннера, поэтому изменение между ними
+		// не потеряется, а придет повторно следующим событием.
+		name, data := s.currentBanner(r, params, isAdmin)
+		sw.event(s.changes.EventID(sub.Seq()), name, data)
+	}
+
+	if err := sw.flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(s.streams.cfg.Heartbeat)
+	defer heartbeat.Stop()
+
+	var deadline <-chan time.Time
+
+	if s.streams.cfg.MaxDuration != 0 {
+		t := time.NewTimer(s.streams.cfg.MaxDuration)
+		defer t.Stop()
+
+		deadline = t.C
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.streams.done:
+			return
+		case <-deadline:
+			return
+		case <-heartbeat.C:
+			sw.comment("ping")
+		case e, ok := <-sub.C():
+			if !ok {
+				// Клиент переподключится с Last-Event-ID и получит пропущенное из истории.
+				if err := sub.Err(); err != nil {
+					logger.FromContext(r.Context()).Warnf("stream closed: %s", err.Error())
+				}
+
+				return
+			}
+
+			name, data, ok := streamEvent(e, filter, isAdmin)
+			if !ok {
+				continue
+			}
+
+			sw.event(s.changes.EventID(e.Seq), name, data)
+		}
+
+		if err := sw.flush(); err != nil {
+			logger.FromContext(r.Context()).Debugf("stream write error: %s", err.Error())
+
+			return
+		}
+	}
+}
+
+// currentBanner возвращает событие с баннером, который сейчас отдал бы
+// GET /user_banner, или null, если баннера нет.
+func (s Server) currentBanner(r *http.Request, params oapi.GetUserBannerStreamParams,
+	isAdmin bool,
+) (string, interface{}) {
+	resp, err := s.bannerService.GetBanner(r.Context(), bannerservice.GetBannerRequest{ //nolint:exhaustruct
+		FeatureID: params.FeatureId,
+		Tags:      []int{params.TagId},
+		IsAdmin:   isAdmin,
+	})
+	if err != nil && !errors.Is(err, bannerservice.ErrNotFound) {
+		logger.FromContext(r.Context()).Errorf("stream get banner error: %s", err.Error())
+
+		return streamEventError, Error{err.Error()}
+	}
+
+	if len(resp.Banners) == 0 {
+		return streamEventBanner, nil
+	}
+
+	b := resp.Banners[rand.Intn(len(resp.Banners))] //nolint:gosec
+
+	return streamEventBanner, StreamBanner{BannerID: b.ID, Content: b.Content}
+}
+
+// streamEvent переводит изменение баннера в событие потока для фичи и тэга
+// filter. Для пользователя выключенный баннер считается удаленным, как и
+// баннер, который перестал относиться к фиче или тэгу.
+func streamEvent(e changebus.Event, filter changebus.Filter, isAdmin bool) (string, interface{}, bool) {
+	visible := func(b *models.Banner) bool {
+		return b != nil && filter.MatchBanner(*b) && (isAdmin || b.Active)
+	}
+
+	switch cur, prev := &e.Banner, e.Previous; {
+	case e.Type == changebus.Deleted:
+		return streamEventDeleted, StreamBanner{BannerID: e.Banner.ID, Content: nil}, true
+	case visible(cur) && e.Type == changebus.Created:
+		return streamEventCreated, StreamBanner{BannerID: e.Banner.ID, Content: e.Banner.Content}, true
+	case visible(cur):
+		return streamEventUpdated, StreamBanner{BannerID: e.Banner.ID, Content: e.Banner.Content}, true
+	case visible(prev):
+		return streamEventDeleted, StreamBanner{BannerID: e.Banner.ID, Content: nil}, true
+	default:
+		return "", nil, false
+	}
+}
+
+// sseWriter пишет события Server-Sent Events. Ошибка записи запоминается
+// и возвращается из flush. У каждой записи свой дедлайн: общий WriteTimeout
+// сервера оборвал бы поток, а без дедлайна завис бы на клиенте, который
+// перестал читать.
+type sseWriter struct {
+	w            io.Writer
+	rc           *http.ResponseController
+	writeTimeout time.Duration
+	err          error
+}
+
+func (sw *sseWriter) write(format string, args ...interface{}) {
+	if sw.err != nil {
+		return
+	}
+
+	if sw.writeTimeout != 0 {
+		if err := sw.rc.SetWriteDeadline(time.Now().Add(sw.writeTimeout)); err != nil &&
+			!errors.Is(err, http.ErrNotSupported) {
+			sw.err = err
+
+			return
+		}
+	}
+
+	_, sw.err = fmt.Fprintf(sw.w, format, args...)
+}
+
+func (sw *sseWriter) retry(d time.Duration) {
+	if d != 0 {
+		sw.write("retry: %d\n\n", d.Milliseconds())
+	}
+}
+
+func (sw *sseWriter) comment(text string) {
+	sw.write(": %s\n\n", text)
+}
+
+func (sw *sseWriter) event(id, name string, data interface{}) {
+	bts, err := json.Marshal(data)
+	if err != nil {
+		bts, _ = json.Marshal(Error{err.Error()}) //nolint:errchkjson
+		name = streamEventError
+	}
+
+	sw.write("id: %s\nevent: %s\ndata: %s\n\n", id, name, bts)
+}
+
+func (sw *sseWriter) flush() error {
+	if sw.err != nil {
+		return sw.err
+	}
+
+	if err := sw.rc.Flush(); err != nil {
+		return fmt.Errorf("flush error: %w", err)
+	}
+
+	return nil
+}
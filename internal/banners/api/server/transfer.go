@@ -0,0 +1,272 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/services/bannerservice"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+)
+
+const (
+	maxImportSize     = 64 << 20
+	maxImportLineSize = 1 << 20
+	// exportFlushEvery - через сколько строк выгрузки данные отправляются клиенту.
+	exportFlushEvery = 100
+)
+
+// Выгрузка баннеров в формате NDJSON
+// (GET /banner/export).
+func (s Server) GetBannerExport(w http.ResponseWriter, r *http.Request, params oapi.GetBannerExportParams) {
+	w.Header().Add("Content-Type", "application/json")
+
+	if params.Token == nil {
+		handleError(w, fmt.Errorf("admin token required"), http.StatusUnauthorized) //nolint:perfsprint
+
+		return
+	}
+
+	isAdmin, err := s.authService.Auth(*params.Token)
+	if err != nil {
+		handleError(w, fmt.Errorf("authorization error: %w", err), http.StatusUnauthorized)
+
+		return
+	}
+
+	if !isAdmin {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	req, err := adminBannersRequest(exportParams(params))
+	if err != nil {
+		handleError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	req.IsAdmin = isAdmin
+
+	rc := http.NewResponseController(w) //nolint:bodyclose
+	enc := json.NewEncoder(w)
+	written := 0
+
+	// Статус отправляется вместе с первой строкой, чтобы ошибку до начала
+	// выгрузки можно было вернуть обычным ответом.
+	start := func() {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+	}
+
+	err = s.bannerService.ExportBanners(r.Context(), req, func(b models.Banner) error {
+		if written == 0 {
+			start()
+		}
+
+		written++
+
+		if err := enc.Encode(b); err != nil {
+			return fmt.Errorf("encode error: %w", err)
+		}
+
+		if written%exportFlushEvery == 0 {
+			rc.Flush() //nolint:errcheck
+		}
+
+		return nil
+	})
+	if err != nil {
+		if written == 0 {
+			handleError(w, fmt.Errorf("export banners error: %w", err), errorCode(err))
+
+			return
+		}
+
+		// Статус уже отправлен, поэтому соединение обрывается: клиент не
+		// должен принять неполную выгрузку за полную.
+		logger.FromContext(r.Context()).Errorf("export banners error after %d banners: %s", written, err.Error())
+		panic(http.ErrAbortHandler)
+	}
+
+	if written == 0 {
+		start()
+	}
+}
+
+// Загрузка баннеров в формате NDJSON
+// (POST /banner/import).
+func (s Server) PostBannerImport(w http.ResponseWriter, r *http.Request, params oapi.PostBannerImportParams) { //nolint:cyclop
+	w.Header().Add("Content-Type", "application/json")
+
+	if params.Token == nil {
+		handleError(w, fmt.Errorf("admin token required"), http.StatusUnauthorized) //nolint:perfsprint
+
+		return
+	}
+
+	isAdmin, err := s.authService.Auth(*params.Token)
+	if err != nil {
+		handleError(w, fmt.Errorf("authorization error: %w", err), http.StatusUnauthorized)
+
+		return
+	}
+
+	if !isAdmin {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	req := bannerservice.ImportRequest{
+		Lines:      nil,
+		Upsert:     params.Upsert != nil && *params.Upsert,
+		DryRun:     params.DryRun != nil && *params.DryRun,
+		BestEffort: false,
+	}
+
+	if params.Mode != nil {
+		switch *params.Mode {
+		case oapi.Atomic:
+		case oapi.BestEffort:
+			req.BestEffort = true
+		default:
+			handleError(w, fmt.Errorf("unknown import mode %q", *params.Mode), http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	req.Lines, err = readImport(http.MaxBytesReader(w, r.Body, maxImportSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			handleError(w, fmt.Errorf("import body exceeds %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+
+			return
+		}
+
+		handleError(w, fmt.Errorf("read import error: %w", err), http.StatusBadRequest)
+
+		return
+	}
+
+	resp, err := s.bannerService.ImportBanners(r.Context(), req)
+	rejected := errors.Is(err, bannerservice.ErrInvalidImport)
+
+	if err != nil && !rejected {
+		handleError(w, fmt.Errorf("import banners error: %w", err), errorCode(err))
+
+		return
+	}
+
+	report := ImportResponse{
+		Total:   resp.Total,
+		Created: resp.Created,
+		Updated: resp.Updated,
+		Failed:  resp.Failed,
+		DryRun:  resp.DryRun,
+		Errors:  make([]ImportErrorResponse, 0, len(resp.Errors)),
+	}
+
+	for _, e := range resp.Errors {
+		report.Errors = append(report.Errors, ImportErrorResponse{Line: e.Line, Error: e.Error})
+	}
+
+	bts, err := json.Marshal(report)
+	if err != nil {
+		handleError(w, fmt.Errorf("encode error: %w", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	if rejected {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	w.Write(bts) //nolint:errcheck
+}
+
+// readImport разбирает NDJSON построчно. Ошибка разбора строки попадает в
+// отчет импорта, ошибка чтения тела прерывает разбор.
+func readImport(r io.Reader) ([]bannerservice.ImportLine, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), maxImportLineSize) //nolint:gomnd
+
+	var lines []bannerservice.ImportLine
+
+	for n := 1; sc.Scan(); n++ {
+		text := bytes.TrimSpace(sc.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var b models.Banner
+
+		dec := json.NewDecoder(bytes.NewReader(text))
+		dec.DisallowUnknownFields()
+
+		err := dec.Decode(&b)
+		if err != nil {
+			err = fmt.Errorf("decode error: %w", err)
+		}
+
+		// Идентификаторы выдает БД окружения, в которое загружаются баннеры.
+		b.ID = 0
+
+		lines = append(lines, bannerservice.ImportLine{Line: n, Banner: b, Err: err})
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("scan error: %w", err)
+	}
+
+	return lines, nil
+}
+
+// exportParams переводит параметры выгрузки в параметры GET /banner,
+// чтобы фильтры разбирались одинаково.
+func exportParams(p oapi.GetBannerExportParams) oapi.GetBannerParams {
+	params := oapi.GetBannerParams{ //nolint:exhaustruct
+		BannerIds:   p.BannerIds,
+		FeatureId:   p.FeatureId,
+		TagId:       p.TagId,
+		FeatureIds:  p.FeatureIds,
+		TagIds:      p.TagIds,
+		IsActive:    p.IsActive,
+		CreatedFrom: p.CreatedFrom,
+		CreatedTo:   p.CreatedTo,
+		UpdatedFrom: p.UpdatedFrom,
+		UpdatedTo:   p.UpdatedTo,
+		Q:           p.Q,
+		Content:     p.Content,
+		ContentPath: p.ContentPath,
+		Token:       p.Token,
+	}
+
+	if p.TagMatch != nil {
+		m := oapi.GetBannerParamsTagMatch(*p.TagMatch)
+		params.TagMatch = &m
+	}
+
+	if p.Sort != nil {
+		s := oapi.GetBannerParamsSort(*p.Sort)
+		params.Sort = &s
+	}
+
+	if p.Order != nil {
+		o := oapi.GetBannerParamsOrder(*p.Order)
+		params.Order = &o
+	}
+
+	return params
+}
@@ -0,0 +1,236 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/services/webhookservice"
+)
+
+var errWebhooksDisabled = errors.New("webhooks are disabled")
+
+const (
+	defaultDeliveriesLimit = 100
+	maxDeliveriesLimit     = 1000
+)
+
+// Получение зарегистрированных вебхуков
+// (GET /webhooks).
+func (s Server) GetWebhooks(w http.ResponseWriter, r *http.Request, params oapi.GetWebhooksParams) {
+	w.Header().Add("Content-Type", "application/json")
+
+	if !s.checkAdmin(w, params.Token) || !s.checkWebhooks(w) {
+		return
+	}
+
+	webhooks, err := s.webhookService.GetWebhooks(r.Context())
+	if err != nil {
+		handleError(w, fmt.Errorf("get webhooks error: %w", err), errorCode(err))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, webhooks)
+}
+
+// Регистрация вебхука
+// (POST /webhooks).
+func (s Server) PostWebhooks(w http.ResponseWriter, r *http.Request, params oapi.PostWebhooksParams) {
+	w.Header().Add("Content-Type", "application/json")
+
+	if !s.checkAdmin(w, params.Token) || !s.checkWebhooks(w) {
+		return
+	}
+
+	var b oapi.PostWebhooksJSONBody
+
+	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+		handleError(w, fmt.Errorf("decode error: %w", err), http.StatusBadRequest)
+
+		return
+	}
+
+	req := webhookservice.CreateWebhookRequest{ //nolint:exhaustruct
+		URL:    b.Url,
+		Events: make([]string, 0, len(b.Events)),
+	}
+
+	for _, e := range b.Events {
+		req.Events = append(req.Events, string(e))
+	}
+
+	if b.FeatureIds != nil {
+		req.FeatureIDs = *b.FeatureIds
+	}
+
+	if b.Secret != nil {
+		req.Secret = *b.Secret
+	}
+
+	webhook, err := s.webhookService.CreateWebhook(r.Context(), req)
+	if err != nil {
+		handleError(w, fmt.Errorf("create webhook error: %w", err), errorCode(err))
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, webhook)
+}
+
+// Удаление вебхука вместе с журналом доставок
+// (DELETE /webhooks/{id}).
+func (s Server) DeleteWebhooksId(w http.ResponseWriter, r *http.Request, id int, //nolint:revive,stylecheck
+	params oapi.DeleteWebhooksIdParams,
+) {
+	w.Header().Add("Content-Type", "application/json")
+
+	if !s.checkAdmin(w, params.Token) || !s.checkWebhooks(w) {
+		return
+	}
+
+	if err := s.webhookService.DeleteWebhook(r.Context(), id); err != nil {
+		if errors.Is(err, webhookservice.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		handleError(w, fmt.Errorf("delete webhook error: %w", err), errorCode(err))
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Журнал доставок вебхука
+// (GET /webhooks/{id}/deliveries).
+func (s Server) GetWebhooksIdDeliveries(w http.ResponseWriter, r *http.Request, id int, //nolint:revive,stylecheck
+	params oapi.GetWebhooksIdDeliveriesParams,
+) {
+	w.Header().Add("Content-Type", "application/json")
+
+	if !s.checkAdmin(w, params.Token) || !s.checkWebhooks(w) {
+		return
+	}
+
+	req := webhookservice.GetDeliveriesRequest{ //nolint:exhaustruct
+		WebhookID: id,
+		Limit:     defaultDeliveriesLimit,
+	}
+
+	if params.Status != nil {
+		req.Status = string(*params.Status)
+	}
+
+	if params.Limit != nil {
+		req.Limit = *params.Limit
+	}
+
+	if params.Offset != nil {
+		req.Offset = *params.Offset
+	}
+
+	if req.Limit <= 0 || req.Limit > maxDeliveriesLimit || req.Offset < 0 {
+		handleError(w, fmt.Errorf("limit must be in [1, %d], offset must be non-negative", //nolint:goerr113
+			maxDeliveriesLimit), http.StatusBadRequest)
+
+		return
+	}
+
+	deliveries, err := s.webhookService.GetDeliveries(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, webhookservice.ErrNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		handleError(w, fmt.Errorf("get deliveries error: %w", err), errorCode(err))
+
+		return
+	}
+
+	resp := make([]DeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, deliveryResponse(d))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// checkAdmin проверяет токен админа и при отказе сам пишет ответ.
+func (s Server) checkAdmin(w http.ResponseWriter, token *string) bool {
+	if token == nil {
+		handleError(w, fmt.Errorf("admin token required"), http.StatusUnauthorized) //nolint:perfsprint
+
+		return false
+	}
+
+	isAdmin, err := s.authService.Auth(*token)
+	if err != nil {
+		handleError(w, fmt.Errorf("authorization error: %w", err), http.StatusUnauthorized)
+
+		return false
+	}
+
+	if !isAdmin {
+		w.WriteHeader(http.StatusForbidden)
+
+		return false
+	}
+
+	return true
+}
+
+// checkWebhooks при выключенных вебхуках сам пишет ответ 404.
+func (s Server) checkWebhooks(w http.ResponseWriter) bool {
+	if s.webhookService == nil {
+		handleError(w, errWebhooksDisabled, http.StatusNotFound)
+
+		return false
+	}
+
+	return true
+}
+
+func deliveryResponse(d models.WebhookDelivery) DeliveryResponse {
+	resp := DeliveryResponse{
+		ID:            d.ID,
+		Event:         d.Event,
+		BannerID:      d.BannerID,
+		Status:        d.Status,
+		Attempts:      d.Attempts,
+		NextAttemptAt: nil,
+		ResponseCode:  d.ResponseCode,
+		LastError:     d.LastError,
+		CreatedAt:     d.CreatedAt,
+		DeliveredAt:   nil,
+	}
+
+	if d.Status == models.DeliveryPending {
+		resp.NextAttemptAt = &d.NextAttemptAt
+	}
+
+	if !d.DeliveredAt.IsZero() {
+		resp.DeliveredAt = &d.DeliveredAt
+	}
+
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	bts, err := json.Marshal(v)
+	if err != nil {
+		handleError(w, fmt.Errorf("encode error: %w", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(code)
+	w.Write(bts) //nolint:errcheck
+}
@@ -0,0 +1,35 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Leopold1975/banners_control/internal/banners/api/oapi"
+	"github.com/Leopold1975/banners_control/internal/banners/api/server"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+type adminAuthMock struct {
+	server.AuthService
+}
+
+func (adminAuthMock) Auth(string) (bool, error) {
+	return true, nil
+}
+
+func TestWebhooksDisabled(t *testing.T) {
+	lg, err := logger.New(config.Logger{Level: "info"}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	s := server.New(config.Server{}, nil, adminAuthMock{}, nil, nil, nil, nil, nil, nil, nil, lg) //nolint:exhaustruct
+
+	token := "admin_token"
+	w := httptest.NewRecorder()
+
+	s.GetWebhooks(w, httptest.NewRequest(http.MethodGet, "/v1/webhooks", nil), oapi.GetWebhooksParams{Token: &token})
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.JSONEq(t, `{"error":"webhooks are disabled"}`, w.Body.String())
+}
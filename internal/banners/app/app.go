@@ -3,27 +3,52 @@ package app
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
+	"github.com/Leopold1975/banners_control/internal/banners/api/grpcserver"
 	"github.com/Leopold1975/banners_control/internal/banners/api/server"
+	cb "github.com/Leopold1975/banners_control/internal/banners/repository/bannercache/breaker"
 	"github.com/Leopold1975/banners_control/internal/banners/repository/bannercache/redis"
+	rb "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo/breaker"
 	br "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo/postgres"
+	sp "github.com/Leopold1975/banners_control/internal/banners/repository/statsrepo/postgres"
+	sr "github.com/Leopold1975/banners_control/internal/banners/repository/statsrepo/redis"
 	ur "github.com/Leopold1975/banners_control/internal/banners/repository/userrepo/postgres"
+	wr "github.com/Leopold1975/banners_control/internal/banners/repository/webhookrepo/postgres"
 	"github.com/Leopold1975/banners_control/internal/banners/services/authservice"
 	"github.com/Leopold1975/banners_control/internal/banners/services/bannerservice"
+	"github.com/Leopold1975/banners_control/internal/banners/services/changebus"
+	"github.com/Leopold1975/banners_control/internal/banners/services/eventrelay"
+	"github.com/Leopold1975/banners_control/internal/banners/services/healthservice"
+	"github.com/Leopold1975/banners_control/internal/banners/services/statsservice"
+	"github.com/Leopold1975/banners_control/internal/banners/services/webhookservice"
 	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/internal/pkg/leader"
+	"github.com/Leopold1975/banners_control/internal/pkg/metrics"
+	"github.com/Leopold1975/banners_control/internal/pkg/pgtools"
+	"github.com/Leopold1975/banners_control/internal/pkg/ratelimit"
+	"github.com/Leopold1975/banners_control/internal/pkg/redistools"
+	"github.com/Leopold1975/banners_control/internal/pkg/tracing"
 	"github.com/Leopold1975/banners_control/pkg/logger"
 )
 
+// migrationRetryInterval - пауза между попытками применить миграции,
+// если БД была недоступна при запуске.
+const migrationRetryInterval = 5 * time.Second
+
 type Server interface {
 	Start(context.Context) error
 	Shutdown(context.Context) error
 }
 
 type BannersApp struct {
-	s   Server
-	lg  logger.Logger
-	cfg config.Config
+	s        Server
+	gs       Server
+	ms       Server
+	lg       logger.Logger
+	cfg      config.Config
+	shutdown tracing.ShutdownFunc
 }
 
 func New(ctx context.Context, cfg config.Config) (BannersApp, error) {
@@ -32,36 +57,353 @@ func New(ctx context.Context, cfg config.Config) (BannersApp, error) {
 		return BannersApp{}, fmt.Errorf("can't get logger error: %w", err)
 	}
 
-	bannerRepo, err := br.New(ctx, cfg.PostgresDB)
+	// Фоновые задачи получают базовый логгер через контекст.
+	ctx = logger.WithContext(ctx, lg)
+
+	shutdownTracing, err := tracing.New(ctx, cfg.Tracing)
 	if err != nil {
-		return BannersApp{}, fmt.Errorf("postgres banner repo initializing error: %w", err)
+		return BannersApp{}, fmt.Errorf("tracing initializing error: %w", err)
 	}
 
-	bc, err := redis.New(ctx, cfg.RedisCache)
+	m := metrics.New()
+
+	bannerRepo, err := newBannerRepo(ctx, cfg, m, lg)
+	if err != nil {
+		return BannersApp{}, err
+	}
+
+	bc, err := newBannerCache(ctx, cfg, m, lg)
+	if err != nil {
+		return BannersApp{}, err
+	}
+
+	elector, err := leader.New(ctx, cfg.Leader, cfg.RedisCache, lg)
+	if err != nil {
+		return BannersApp{}, fmt.Errorf("leader elector initializing error: %w", err)
+	}
+
+	go elector.Run(ctx)
+
+	repo, cache := protect(cfg, bannerRepo, bc, lg)
+
+	changes, err := newChanges(ctx, cfg)
 	if err != nil {
-		return BannersApp{}, fmt.Errorf("redis banner cache initializing error: %w", err)
+		return BannersApp{}, err
 	}
 
-	bannerService := bannerservice.New(bannerRepo, bc, lg)
+	go changes.Run(ctx)
+
+	bannerService := bannerservice.New(repo, cache, cfg.RedisCache, m, changes)
 
-	go bannerService.BackroundRefresh(ctx, cfg.RedisCache.ExpTime)
+	go bannerService.BackroundRefresh(ctx, cfg.RedisCache.ExpTime, elector)
 
 	userRepo, err := ur.New(ctx, cfg.PostgresDB)
 	if err != nil {
-		return BannersApp{}, fmt.Errorf("postgres user repo initializing error: %w", err)
+		if !cfg.Breaker.Enabled {
+			return BannersApp{}, fmt.Errorf("postgres user repo initializing error: %w", err)
+		}
+
+		lg.Warnf("postgres user repo unavailable: %s", err.Error())
+
+		userRepo, err = ur.NewUnchecked(ctx, cfg.PostgresDB)
+		if err != nil {
+			return BannersApp{}, fmt.Errorf("postgres user repo initializing error: %w", err)
+		}
 	}
 
+	m.RegisterPgxPool("users", userRepo.Stat)
+
 	authService := authservice.New(userRepo, cfg.Auth)
 
-	s := server.New(cfg.Server, bannerService, authService, lg)
+	healthService := healthservice.New(bannerRepo, bc, cfg)
+
+	webhookService, err := newWebhookService(ctx, cfg, m, lg)
+	if err != nil {
+		return BannersApp{}, err
+	}
+
+	if webhookService != nil {
+		go webhookService.Run(ctx)
+	}
+
+	if cfg.Events.Enabled {
+		pub, err := newEventPublisher(ctx, cfg)
+		if err != nil {
+			return BannersApp{}, err
+		}
+
+		go eventrelay.New(bannerRepo, pub, cfg.Events).Run(ctx)
+	}
+
+	go eventrelay.NewPurger(bannerRepo, cfg.Events).Run(ctx)
+
+	statsService, err := newStatsService(ctx, cfg, m, lg)
+	if err != nil {
+		return BannersApp{}, err
+	}
+
+	if statsService != nil {
+		go statsService.Run(ctx)
+	}
+
+	rl, err := newRateLimiter(cfg)
+	if err != nil {
+		return BannersApp{}, err
+	}
+
+	// Выключенный сервис передается как nil интерфейса: его маршруты отвечают 404.
+	var ws server.WebhookService
+	if webhookService != nil {
+		ws = webhookService
+	}
+
+	var ss server.StatsService
+	if statsService != nil {
+		ss = statsService
+	}
+
+	s := server.New(cfg.Server, bannerService, authService, elector, healthService, changes, ws,
+		ss, rl, m, lg)
+
+	var gs Server
+	if cfg.GRPC.Enabled {
+		gs = grpcserver.New(cfg.GRPC, bannerService, authService, changes, ss, lg)
+	}
+
+	var ms Server
+	if cfg.Metrics.Enabled {
+		ms = metrics.NewServer(cfg.Metrics, m)
+	}
 
 	return BannersApp{
-		s:   s,
-		lg:  lg,
-		cfg: cfg,
+		s:        s,
+		gs:       gs,
+		ms:       ms,
+		lg:       lg,
+		cfg:      cfg,
+		shutdown: shutdownTracing,
 	}, nil
 }
 
+// newBannerRepo подключается к БД. Если включен автоматический выключатель,
+// недоступная при старте БД не мешает запуску: сервис работает из кэша,
+// пока соединение не восстановится.
+func newBannerRepo(ctx context.Context, cfg config.Config, m *metrics.Metrics,
+	lg logger.Logger,
+) (br.BannersPostgresRepo, error) {
+	bannerRepo, err := br.New(ctx, cfg.PostgresDB)
+	if err != nil {
+		if !cfg.Breaker.Enabled {
+			return br.BannersPostgresRepo{}, fmt.Errorf("postgres banner repo initializing error: %w", err)
+		}
+
+		lg.Warnf("postgres banner repo unavailable, starting in cache-only mode: %s", err.Error())
+
+		bannerRepo, err = br.NewUnchecked(ctx, cfg.PostgresDB)
+		if err != nil {
+			return br.BannersPostgresRepo{}, fmt.Errorf("postgres banner repo initializing error: %w", err)
+		}
+
+		go applyMigrationLater(ctx, cfg.PostgresDB, lg)
+	}
+
+	m.RegisterPgxPool("banners", bannerRepo.Stat)
+
+	return bannerRepo, nil
+}
+
+// applyMigrationLater применяет миграции, которые не удалось применить при
+// запуске, как только БД станет доступна: иначе после восстановления БД
+// сервис работал бы со старой схемой.
+func applyMigrationLater(ctx context.Context, cfg config.PostgresDB, lg logger.Logger) {
+	t := time.NewTicker(migrationRetryInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		if err := pgtools.ApplyMigration(cfg); err != nil {
+			lg.Warnf("apply migration error: %s", err.Error())
+
+			continue
+		}
+
+		lg.Info("migrations applied")
+
+		return
+	}
+}
+
+// newBannerCache подключается к Redis. Если включен автоматический выключатель,
+// недоступный при старте Redis не мешает запуску: сервис работает напрямую с БД.
+func newBannerCache(ctx context.Context, cfg config.Config, m *metrics.Metrics,
+	lg logger.Logger,
+) (redis.BannerCache, error) {
+	bc, err := redis.New(ctx, cfg.RedisCache)
+	if err != nil {
+		if !cfg.Breaker.Enabled {
+			return redis.BannerCache{}, fmt.Errorf("redis banner cache initializing error: %w", err)
+		}
+
+		lg.Warnf("redis banner cache unavailable, starting in db-only mode: %s", err.Error())
+
+		bc, err = redis.NewUnchecked(cfg.RedisCache)
+		if err != nil {
+			return redis.BannerCache{}, fmt.Errorf("redis banner cache initializing error: %w", err)
+		}
+	}
+
+	m.RegisterRedisPool("cache", bc.PoolStats)
+
+	return bc, nil
+}
+
+// newWebhookService возвращает nil, если вебхуки выключены. Как и для хранилища
+// баннеров, при включенном выключателе недоступная БД не мешает запуску.
+func newWebhookService(ctx context.Context, cfg config.Config, m *metrics.Metrics,
+	lg logger.Logger,
+) (*webhookservice.WebhookService, error) {
+	if !cfg.Webhooks.Enabled {
+		return nil, nil
+	}
+
+	webhookRepo, err := wr.New(ctx, cfg.PostgresDB)
+	if err != nil {
+		if !cfg.Breaker.Enabled {
+			return nil, fmt.Errorf("postgres webhook repo initializing error: %w", err)
+		}
+
+		lg.Warnf("postgres webhook repo unavailable: %s", err.Error())
+
+		webhookRepo, err = wr.NewUnchecked(ctx, cfg.PostgresDB)
+		if err != nil {
+			return nil, fmt.Errorf("postgres webhook repo initializing error: %w", err)
+		}
+	}
+
+	m.RegisterPgxPool("webhooks", webhookRepo.Stat)
+
+	return webhookservice.New(webhookRepo, cfg.Webhooks), nil
+}
+
+// newChanges создает шину изменений баннеров поверх потока Redis.
+func newChanges(ctx context.Context, cfg config.Config) (*changebus.Bus, error) {
+	rdb, err := redistools.NewClient(cfg.RedisCache)
+	if err != nil {
+		return nil, fmt.Errorf("changes redis client initializing error: %w", err)
+	}
+
+	return changebus.New(ctx, rdb, cfg.Changes), nil
+}
+
+func newStatsService(ctx context.Context, cfg config.Config, m *metrics.Metrics,
+	lg logger.Logger,
+) (*statsservice.StatsService, error) {
+	if !cfg.Stats.Enabled {
+		return nil, nil
+	}
+
+	counters, err := sr.New(ctx, cfg.RedisCache)
+	if err != nil {
+		if !cfg.Breaker.Enabled {
+			return nil, fmt.Errorf("redis stats counters initializing error: %w", err)
+		}
+
+		lg.Warnf("redis stats counters unavailable: %s", err.Error())
+
+		counters, err = sr.NewUnchecked(cfg.RedisCache)
+		if err != nil {
+			return nil, fmt.Errorf("redis stats counters initializing error: %w", err)
+		}
+	}
+
+	statsRepo, err := sp.New(ctx, cfg.PostgresDB)
+	if err != nil {
+		if !cfg.Breaker.Enabled {
+			return nil, fmt.Errorf("postgres stats repo initializing error: %w", err)
+		}
+
+		lg.Warnf("postgres stats repo unavailable: %s", err.Error())
+
+		statsRepo, err = sp.NewUnchecked(ctx, cfg.PostgresDB)
+		if err != nil {
+			return nil, fmt.Errorf("postgres stats repo initializing error: %w", err)
+		}
+	}
+
+	m.RegisterPgxPool("stats", statsRepo.Stat)
+
+	return statsservice.New(counters, statsRepo, cfg.Stats), nil
+}
+
+func newEventPublisher(ctx context.Context, cfg config.Config) (eventrelay.Publisher, error) { //nolint:ireturn
+	switch cfg.Events.Publisher {
+	case eventrelay.PublisherStdout:
+		return eventrelay.NewWriter(os.Stdout), nil
+	case eventrelay.PublisherFile:
+		pub, err := eventrelay.NewFile(cfg.Events.File)
+		if err != nil {
+			return nil, fmt.Errorf("event publisher initializing error: %w", err)
+		}
+
+		return pub, nil
+	case eventrelay.PublisherRedis:
+		rdb, err := redistools.NewClient(cfg.RedisCache)
+		if err != nil {
+			return nil, fmt.Errorf("event publisher redis client initializing error: %w", err)
+		}
+
+		return eventrelay.NewRedis(rdb, cfg.Events.Redis), nil
+	case eventrelay.PublisherNATS:
+		pub, err := eventrelay.NewNATS(ctx, cfg.Events.NATS)
+		if err != nil {
+			return nil, fmt.Errorf("event publisher initializing error: %w", err)
+		}
+
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", eventrelay.ErrUnknownPublisher, cfg.Events.Publisher)
+	}
+}
+
+// newRateLimiter возвращает nil, если ограничение частоты запросов выключено.
+func newRateLimiter(cfg config.Config) (server.RateLimiter, error) { //nolint:ireturn
+	if !cfg.RateLimit.Enabled {
+		return nil, nil
+	}
+
+	switch cfg.RateLimit.Store {
+	case ratelimit.StoreMemory:
+		return ratelimit.NewPolicy(ratelimit.NewMemory(), cfg.RateLimit), nil
+	case ratelimit.StoreRedis:
+		rdb, err := redistools.NewClient(cfg.RedisCache)
+		if err != nil {
+			return nil, fmt.Errorf("rate limit redis client initializing error: %w", err)
+		}
+
+		return ratelimit.NewPolicy(ratelimit.NewRedis(rdb), cfg.RateLimit), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ratelimit.ErrUnknownStore, cfg.RateLimit.Store)
+	}
+}
+
+// protect оборачивает хранилище и кэш автоматическими выключателями, если они включены.
+// Проверки готовности работают с исходными реализациями, чтобы видеть восстановление
+// зависимостей независимо от состояния выключателей.
+func protect(cfg config.Config, bannerRepo br.BannersPostgresRepo, bc redis.BannerCache, //nolint:ireturn
+	lg logger.Logger,
+) (bannerservice.Repository, bannerservice.Cache) {
+	if !cfg.Breaker.Enabled {
+		return bannerRepo, bc
+	}
+
+	return rb.New(bannerRepo, cfg.Breaker, lg), cb.New(bc, cfg.Breaker, lg)
+}
+
 func (ba *BannersApp) Run(ctx context.Context) {
 	ba.lg.Infof("STARTED SERVER ON %s", ba.cfg.Server.Addr)
 
@@ -74,9 +416,31 @@ func (ba *BannersApp) Run(ctx context.Context) {
 		}
 	}()
 
+	if ba.gs != nil {
+		ba.lg.Infof("STARTED GRPC SERVER ON %s", ba.cfg.GRPC.Addr)
+
+		go func() {
+			if err := ba.gs.Start(ctx); err != nil {
+				ba.lg.Errorf("grpc server start error: %s", err.Error())
+			}
+		}()
+	}
+
+	if ba.ms != nil {
+		ba.lg.Infof("STARTED METRICS SERVER ON %s", ba.cfg.Metrics.Addr)
+
+		go func() {
+			if err := ba.ms.Start(ctx); err != nil {
+				ba.lg.Errorf("metrics server start error: %s", err.Error())
+			}
+		}()
+	}
+
 	<-ctx.Done()
 
-	ctxS, cancel := context.WithTimeout(context.Background(), time.Second*5) //nolint:gomnd
+	// Время на завершение отсчитывается после drainDelay, иначе при длинной
+	// задержке на закрытие соединений ничего не останется.
+	ctxS, cancel := context.WithTimeout(context.Background(), ba.cfg.Server.DrainDelay+time.Second*5) //nolint:gomnd
 	defer cancel()
 
 	if err := ba.Stop(ctxS); err != nil { //nolint:contextcheck
@@ -89,6 +453,22 @@ func (ba *BannersApp) Stop(ctx context.Context) error {
 		return fmt.Errorf("server shutdown error: %w", err)
 	}
 
+	if ba.gs != nil {
+		if err := ba.gs.Shutdown(ctx); err != nil {
+			return fmt.Errorf("grpc server shutdown error: %w", err)
+		}
+	}
+
+	if ba.ms != nil {
+		if err := ba.ms.Shutdown(ctx); err != nil {
+			return fmt.Errorf("metrics server shutdown error: %w", err)
+		}
+	}
+
+	if err := ba.shutdown(ctx); err != nil {
+		return fmt.Errorf("tracing shutdown error: %w", err)
+	}
+
 	ba.lg.Info("Shutdowned successfully")
 
 	return nil
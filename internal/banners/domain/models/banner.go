@@ -12,4 +12,7 @@ type Banner struct {
 	ID        int64                  `json:"banner_id"`  //nolint:tagliatelle
 	CreatedAt time.Time              `json:"created_at"` //nolint:tagliatelle
 	Content   map[string]interface{} `json:"content"`
+	// ExternalKey - необязательный уникальный ключ баннера для переноса
+	// между окружениями.
+	ExternalKey string `json:"external_key,omitempty"` //nolint:tagliatelle
 }
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// События баннеров. Activated - баннер включен изменением, оно же порождает
+// и Updated. Activated получают только вебхуки, в banner_events его нет.
+const (
+	EventCreated   = "created"
+	EventUpdated   = "updated"
+	EventDeleted   = "deleted"
+	EventActivated = "activated"
+)
+
+// BannerEvent - изменение баннера из таблицы banner_events. ID возрастает
+// в порядке изменений одного баннера, по нему получатель отбрасывает повторы.
+type BannerEvent struct {
+	ID        int64     `json:"event_id"`  //nolint:tagliatelle
+	BannerID  int64     `json:"banner_id"` //nolint:tagliatelle
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"` //nolint:tagliatelle
+	// Data - JSON вида {"event", "occurred_at", "banner", "previous"}, как в теле вебхука.
+	Data json.RawMessage `json:"data"`
+}
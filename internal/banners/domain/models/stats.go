@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// StatsCounter - показы и клики баннера пользователям с тэгом за час Hour.
+type StatsCounter struct {
+	BannerID    int64
+	TagID       int
+	Hour        time.Time
+	Impressions int64
+	Clicks      int64
+}
+
+// BannerStats - статистика баннера за период [From, To).
+type BannerStats struct {
+	BannerID    int64      `json:"banner_id"` //nolint:tagliatelle
+	From        time.Time  `json:"from"`
+	To          time.Time  `json:"to"`
+	Impressions int64      `json:"impressions"`
+	Clicks      int64      `json:"clicks"`
+	CTR         float64    `json:"ctr"`
+	Tags        []TagStats `json:"tags"`
+}
+
+type TagStats struct {
+	TagID       int     `json:"tag_id"` //nolint:tagliatelle
+	Impressions int64   `json:"impressions"`
+	Clicks      int64   `json:"clicks"`
+	CTR         float64 `json:"ctr"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Статусы доставки вебхука.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+type Webhook struct {
+	ID     int      `json:"webhook_id"` //nolint:tagliatelle
+	URL    string   `json:"url"`
+	Secret string   `json:"secret,omitempty"`
+	Events []string `json:"events"`
+	// FeatureIDs ограничивает вебхук баннерами перечисленных фич,
+	// пустой список - все фичи.
+	FeatureIDs []int     `json:"feature_ids"` //nolint:tagliatelle
+	CreatedAt  time.Time `json:"created_at"`  //nolint:tagliatelle
+}
+
+// WebhookDelivery - событие для отправки на вебхук и результат последней попытки.
+type WebhookDelivery struct {
+	ID            int64     `json:"delivery_id"` //nolint:tagliatelle
+	WebhookID     int       `json:"webhook_id"`  //nolint:tagliatelle
+	Event         string    `json:"event"`
+	BannerID      int64     `json:"banner_id"` //nolint:tagliatelle
+	Payload       []byte    `json:"-"`
+	Status        string    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`         //nolint:tagliatelle
+	ResponseCode  int       `json:"response_code,omitempty"` //nolint:tagliatelle
+	LastError     string    `json:"last_error,omitempty"`    //nolint:tagliatelle
+	CreatedAt     time.Time `json:"created_at"`              //nolint:tagliatelle
+	DeliveredAt   time.Time `json:"delivered_at,omitempty"`  //nolint:tagliatelle
+}
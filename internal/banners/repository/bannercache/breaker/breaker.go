@@ -0,0 +1,89 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/bannercache"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+	"github.com/Leopold1975/banners_control/internal/pkg/breaker"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+)
+
+type Cache interface {
+	GetUserBanner(ctx context.Context, featureID int, tagID int) (bannercache.CachedBanner, error)
+	GetUserBanners(context.Context, []bannerrepo.FeatureTag) (map[bannerrepo.FeatureTag]bannercache.CachedBanner, error)
+	CreateBanner(context.Context, models.Banner) error
+	CreateBanners(context.Context, []models.Banner) error
+	DeleteBanner(context.Context, int) error
+	MarkWarm(context.Context) error
+}
+
+// BannerCache защищает кэш автоматическим выключателем: при недоступности
+// Redis запросы сразу завершаются ошибкой breaker.ErrUnavailable и сервис
+// работает напрямую с БД.
+type BannerCache struct {
+	cache Cache
+	b     breaker.Breaker
+}
+
+func New(c Cache, cfg config.Breaker, lg logger.Logger) BannerCache {
+	return BannerCache{
+		cache: c,
+		b: breaker.New("redis", cfg, lg, func(err error) bool {
+			return errors.Is(err, bannerrepo.ErrNotFound)
+		}),
+	}
+}
+
+func (bc BannerCache) GetUserBanner(ctx context.Context, featureID, tagID int) (bannercache.CachedBanner, error) {
+	return breaker.Do(bc.b, func() (bannercache.CachedBanner, error) {
+		return bc.cache.GetUserBanner(ctx, featureID, tagID) //nolint:wrapcheck
+	})
+}
+
+func (bc BannerCache) GetUserBanners(ctx context.Context,
+	pairs []bannerrepo.FeatureTag,
+) (map[bannerrepo.FeatureTag]bannercache.CachedBanner, error) {
+	return breaker.Do(bc.b, func() (map[bannerrepo.FeatureTag]bannercache.CachedBanner, error) {
+		return bc.cache.GetUserBanners(ctx, pairs) //nolint:wrapcheck
+	})
+}
+
+func (bc BannerCache) CreateBanner(ctx context.Context, banner models.Banner) error {
+	_, err := breaker.Do(bc.b, func() (struct{}, error) {
+		return struct{}{}, bc.cache.CreateBanner(ctx, banner) //nolint:wrapcheck
+	})
+
+	return err
+}
+
+func (bc BannerCache) CreateBanners(ctx context.Context, banners []models.Banner) error {
+	_, err := breaker.Do(bc.b, func() (struct{}, error) {
+		return struct{}{}, bc.cache.CreateBanners(ctx, banners) //nolint:wrapcheck
+	})
+
+	return err
+}
+
+func (bc BannerCache) DeleteBanner(ctx context.Context, bannerID int) error {
+	_, err := breaker.Do(bc.b, func() (struct{}, error) {
+		return struct{}{}, bc.cache.DeleteBanner(ctx, bannerID) //nolint:wrapcheck
+	})
+
+	return err
+}
+
+func (bc BannerCache) MarkWarm(ctx context.Context) error {
+	_, err := breaker.Do(bc.b, func() (struct{}, error) {
+		return struct{}{}, bc.cache.MarkWarm(ctx) //nolint:wrapcheck
+	})
+
+	return err
+}
+
+func (bc BannerCache) State() string {
+	return bc.b.State()
+}
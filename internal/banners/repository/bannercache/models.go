@@ -0,0 +1,12 @@
+package bannercache
+
+import (
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+)
+
+type CachedBanner struct {
+	models.Banner
+	CachedAt time.Time `json:"cached_at"` //nolint:tagliatelle
+}
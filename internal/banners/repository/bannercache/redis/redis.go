@@ -6,81 +6,150 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"strconv"
 	"time"
 
 	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/bannercache"
 	"github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
 	"github.com/Leopold1975/banners_control/internal/pkg/config"
 	"github.com/Leopold1975/banners_control/internal/pkg/redistools"
+	"github.com/Leopold1975/banners_control/pkg/logger"
 	"github.com/redis/go-redis/v9"
 )
 
 type BannerCache struct {
-	rdb     *redis.Client
+	rdb     redis.UniversalClient
 	expTime time.Duration
+	// ttl - время хранения баннера: ExpTime и запас на время недоступности БД.
+	ttl time.Duration
 }
 
 func New(ctx context.Context, cfg config.RedisCache) (BannerCache, error) {
-	rdb := redis.NewClient(&redis.Options{ //nolint:exhaustruct
-		Addr:     cfg.Addr,
-		Password: cfg.Password,
-		DB:       cfg.DB,
-	})
+	bc, err := NewUnchecked(cfg)
+	if err != nil {
+		return BannerCache{}, err
+	}
 
-	if err := redistools.Connect(ctx, rdb); err != nil {
+	if err := redistools.Connect(ctx, bc.rdb); err != nil {
 		return BannerCache{}, fmt.Errorf("connect error: %w", err)
 	}
 
+	return bc, nil
+}
+
+// NewUnchecked создает кэш без проверки соединения. Клиент переподключается
+// самостоятельно, поэтому кэш заработает, как только Redis станет доступен.
+func NewUnchecked(cfg config.RedisCache) (BannerCache, error) {
+	rdb, err := redistools.NewClient(cfg)
+	if err != nil {
+		return BannerCache{}, fmt.Errorf("create redis client error: %w", err)
+	}
+
 	return BannerCache{
 		rdb:     rdb,
 		expTime: cfg.ExpTime,
+		ttl:     cfg.ExpTime + cfg.StaleIfError,
 	}, nil
 }
 
+// Ключи индекса и баннеров одной фичи содержат хэш-тэг {featureID}, поэтому
+// в режиме кластера попадают в один слот, и их можно читать одной командой MGET.
+func indexKey(featureID, tagID int) string {
+	return fmt.Sprintf("feature:{%d}:tag:%d", featureID, tagID)
+}
+
+func bannerKey(featureID int, bannerID string) string {
+	return fmt.Sprintf("feature:{%d}:banner:%s", featureID, bannerID)
+}
+
+// bannerFeatureKey хранит фичу баннера, чтобы найти его ключ при удалении по идентификатору.
+func bannerFeatureKey(bannerID int64) string {
+	return fmt.Sprintf("banner:%d:feature", bannerID)
+}
+
+// pipelineBatchSize ограничивает число баннеров в одном конвейере,
+// чтобы не держать в памяти ответы на всю пачку команд сразу.
+const pipelineBatchSize = 500
+
 func (bc BannerCache) CreateBanner(ctx context.Context, banner models.Banner) error {
-	bannerJSON, err := json.Marshal(banner)
-	if err != nil {
-		return fmt.Errorf("marshal error: %w", err)
-	}
+	return bc.CreateBanners(ctx, []models.Banner{banner})
+}
 
-	_, err = bc.rdb.Set(ctx, fmt.Sprintf("banner:%d", banner.ID), bannerJSON, bc.expTime).Result() //nolint:perfsprint
-	if err != nil {
-		return fmt.Errorf("set error: %w", err)
-	}
+// CreateBanners записывает баннеры и индексы по фиче и тэгу конвейером:
+// на каждые pipelineBatchSize баннеров приходится один сетевой запрос.
+// В режиме кластера клиент сам разбивает конвейер по слотам.
+func (bc BannerCache) CreateBanners(ctx context.Context, banners []models.Banner) error {
+	now := time.Now()
+
+	for start := 0; start < len(banners); start += pipelineBatchSize {
+		end := min(start+pipelineBatchSize, len(banners))
 
-	// Создаем "индекс" для ускорения поиска по фиче и тэгу, т.к.
-	// фича и тэг "required" для пользователя.
-	for _, tagID := range banner.Tags {
-		_, err = bc.rdb.SAdd(ctx, fmt.Sprintf("feature:%d:tag:%d", banner.FeatureID, tagID), banner.ID).Result()
+		_, err := bc.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, banner := range banners[start:end] {
+				bannerJSON, err := json.Marshal(bannercache.CachedBanner{
+					Banner:   banner,
+					CachedAt: now,
+				})
+				if err != nil {
+					return fmt.Errorf("marshal error: %w", err)
+				}
+
+				id := strconv.FormatInt(banner.ID, 10)
+
+				pipe.Set(ctx, bannerKey(banner.FeatureID, id), bannerJSON, bc.ttl)
+				pipe.Set(ctx, bannerFeatureKey(banner.ID), banner.FeatureID, bc.ttl)
+
+				// Создаем "индекс" для ускорения поиска по фиче и тэгу, т.к.
+				// фича и тэг "required" для пользователя.
+				for _, tagID := range banner.Tags {
+					pipe.SAdd(ctx, indexKey(banner.FeatureID, tagID), id)
+				}
+			}
+
+			return nil
+		})
 		if err != nil {
-			return fmt.Errorf("sadd error: %w", err)
+			return fmt.Errorf("pipeline error: %w", err)
 		}
 	}
 
 	return nil
 }
 
-func (bc BannerCache) GetUserBanner(ctx context.Context, featureID, tagID int) (models.Banner, error) {
-	banners, err := bc.rdb.SMembers(ctx, fmt.Sprintf("feature:%d:tag:%d", featureID, tagID)).Result()
+func (bc BannerCache) GetUserBanner(ctx context.Context, featureID, tagID int) (bannercache.CachedBanner, error) {
+	ids, err := bc.rdb.SMembers(ctx, indexKey(featureID, tagID)).Result()
+	if err != nil {
+		return bannercache.CachedBanner{}, fmt.Errorf("smembers error: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return bannercache.CachedBanner{}, bannerrepo.ErrNotFound
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, bannerKey(featureID, id))
+	}
+
+	values, err := bc.rdb.MGet(ctx, keys...).Result()
 	if err != nil {
-		return models.Banner{}, fmt.Errorf("smembers error: %w", err)
+		return bannercache.CachedBanner{}, fmt.Errorf("mget error: %w", err)
 	}
 
-	for i := 0; i < len(banners); i++ {
-		j := rand.Intn(len(banners)) //nolint:gosec
+	for _, i := range rand.Perm(len(values)) { //nolint:gosec
+		bannerJSON, ok := values[i].(string)
+		if !ok { // ключ баннера истек или удален
+			logger.FromContext(ctx).Debugw("banner key missing from index", "key", keys[i])
 
-		bannerJSON, err := bc.rdb.Get(ctx, fmt.Sprintf("banner:%s", banners[j])).Result() //nolint:perfsprint
-		if errors.Is(err, redis.Nil) {
 			continue
-		} else if err != nil {
-			return models.Banner{}, fmt.Errorf("get error: %w", err)
 		}
 
-		var banner models.Banner
+		var banner bannercache.CachedBanner
 
 		err = json.Unmarshal([]byte(bannerJSON), &banner)
 		if err != nil {
-			return models.Banner{}, fmt.Errorf("unmarshal error: %w", err)
+			return bannercache.CachedBanner{}, fmt.Errorf("unmarshal error: %w", err)
 		}
 
 		if banner.Active {
@@ -88,20 +157,139 @@ func (bc BannerCache) GetUserBanner(ctx context.Context, featureID, tagID int) (
 		}
 	}
 
-	return models.Banner{}, bannerrepo.ErrNotFound
+	return bannercache.CachedBanner{}, bannerrepo.ErrNotFound
+}
+
+// GetUserBanners ищет баннеры сразу для нескольких пар фича-тэг: индексы читаются
+// одним конвейером, баннеры - вторым. Пары без активного баннера в кэше
+// в результат не попадают.
+func (bc BannerCache) GetUserBanners(ctx context.Context, //nolint:cyclop
+	pairs []bannerrepo.FeatureTag,
+) (map[bannerrepo.FeatureTag]bannercache.CachedBanner, error) {
+	members := make([]*redis.StringSliceCmd, len(pairs))
+
+	_, err := bc.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, p := range pairs {
+			members[i] = pipe.SMembers(ctx, indexKey(p.FeatureID, p.TagID))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("smembers pipeline error: %w", err)
+	}
+
+	values := make([]*redis.SliceCmd, len(pairs))
+
+	_, err = bc.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, p := range pairs {
+			ids := members[i].Val()
+			if len(ids) == 0 {
+				continue
+			}
+
+			keys := make([]string, 0, len(ids))
+			for _, id := range ids {
+				keys = append(keys, bannerKey(p.FeatureID, id))
+			}
+
+			values[i] = pipe.MGet(ctx, keys...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("mget pipeline error: %w", err)
+	}
+
+	res := make(map[bannerrepo.FeatureTag]bannercache.CachedBanner, len(pairs))
+
+	for i, p := range pairs {
+		if values[i] == nil {
+			continue
+		}
+
+		vals := values[i].Val()
+
+		for _, j := range rand.Perm(len(vals)) { //nolint:gosec
+			bannerJSON, ok := vals[j].(string)
+			if !ok {
+				continue
+			}
+
+			var banner bannercache.CachedBanner
+
+			if err := json.Unmarshal([]byte(bannerJSON), &banner); err != nil {
+				return nil, fmt.Errorf("unmarshal error: %w", err)
+			}
+
+			if banner.Active {
+				res[p] = banner
+
+				break
+			}
+		}
+	}
+
+	return res, nil
 }
 
 func (bc BannerCache) DeleteBanner(ctx context.Context, bannerID int) error {
-	key := fmt.Sprintf("banner:%d", bannerID)
+	featureID, err := bc.rdb.Get(ctx, bannerFeatureKey(int64(bannerID))).Int()
+	if errors.Is(err, redis.Nil) {
+		return bannerrepo.ErrNotFound
+	} else if err != nil {
+		return fmt.Errorf("get error: %w", err)
+	}
 
-	deleted, err := bc.rdb.Del(ctx, key).Result()
+	// Ключи лежат в разных слотах, поэтому удаляются отдельными командами.
+	cmds, err := bc.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, bannerKey(featureID, strconv.Itoa(bannerID)))
+		pipe.Del(ctx, bannerFeatureKey(int64(bannerID)))
+
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("del error: %w", err)
 	}
 
+	deleted, _ := cmds[0].(*redis.IntCmd).Result() //nolint:forcetypeassert
 	if deleted == 0 {
 		return bannerrepo.ErrNotFound
 	}
 
 	return nil
 }
+
+func (bc BannerCache) PoolStats() *redis.PoolStats {
+	return bc.rdb.PoolStats()
+}
+
+// warmKey отмечает, что кэш заполнен фоновым обновлением. Ключ живет дольше
+// одного интервала обновления, поэтому пропадает, только если обновления прекратились.
+const warmKey = "banners:warm"
+
+func (bc BannerCache) MarkWarm(ctx context.Context) error {
+	if err := bc.rdb.Set(ctx, warmKey, time.Now().Unix(), 2*bc.expTime).Err(); err != nil { //nolint:gomnd
+		return fmt.Errorf("set error: %w", err)
+	}
+
+	return nil
+}
+
+func (bc BannerCache) IsWarm(ctx context.Context) (bool, error) {
+	n, err := bc.rdb.Exists(ctx, warmKey).Result()
+	if err != nil {
+		return false, fmt.Errorf("exists error: %w", err)
+	}
+
+	return n == 1, nil
+}
+
+func (bc BannerCache) Ping(ctx context.Context) error {
+	if err := bc.rdb.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("ping error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/bannercache/redis"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+)
+
+const benchBanners = 1000
+
+func newCache(tb testing.TB) redis.BannerCache {
+	tb.Helper()
+
+	mr := miniredis.RunT(tb)
+
+	bc, err := redis.New(context.Background(), config.RedisCache{
+		Addr:    mr.Addr(),
+		ExpTime: time.Minute,
+	})
+	require.NoError(tb, err)
+
+	return bc
+}
+
+func testBanners(n int) []models.Banner {
+	banners := make([]models.Banner, 0, n)
+
+	for i := 1; i <= n; i++ {
+		banners = append(banners, models.Banner{
+			ID:        int64(i),
+			FeatureID: i % 100,
+			Tags:      []int{i % 7, i%7 + 1, i%7 + 2, i%7 + 3, i%7 + 4},
+			Active:    true,
+			Content:   map[string]interface{}{"title": "title", "text": "text", "url": "url"},
+		})
+	}
+
+	return banners
+}
+
+func TestCreateBanners(t *testing.T) {
+	ctx := context.Background()
+	bc := newCache(t)
+
+	// Больше одной пачки конвейера.
+	banners := testBanners(501)
+	require.NoError(t, bc.CreateBanners(ctx, banners))
+
+	for _, b := range []models.Banner{banners[0], banners[len(banners)-1]} {
+		got, err := bc.GetUserBanner(ctx, b.FeatureID, b.Tags[4])
+		require.NoError(t, err)
+		require.Equal(t, b.FeatureID, got.FeatureID)
+		require.Contains(t, got.Tags, b.Tags[4])
+		require.False(t, got.CachedAt.IsZero())
+	}
+}
+
+func TestGetUserBanners(t *testing.T) {
+	ctx := context.Background()
+	bc := newCache(t)
+
+	banners := testBanners(3)
+	banners[2].Active = false
+	require.NoError(t, bc.CreateBanners(ctx, banners))
+
+	found := bannerrepo.FeatureTag{FeatureID: banners[0].FeatureID, TagID: banners[0].Tags[0]}
+	inactive := bannerrepo.FeatureTag{FeatureID: banners[2].FeatureID, TagID: banners[2].Tags[0]}
+	missing := bannerrepo.FeatureTag{FeatureID: 99, TagID: 1}
+
+	got, err := bc.GetUserBanners(ctx, []bannerrepo.FeatureTag{found, inactive, missing})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, banners[0].ID, got[found].ID)
+}
+
+func TestDeleteBanner(t *testing.T) {
+	ctx := context.Background()
+	bc := newCache(t)
+
+	banners := testBanners(2)
+	banners[1].FeatureID = banners[0].FeatureID
+	banners[1].Tags = banners[0].Tags
+	require.NoError(t, bc.CreateBanners(ctx, banners))
+
+	require.NoError(t, bc.DeleteBanner(ctx, int(banners[0].ID)))
+	require.ErrorIs(t, bc.DeleteBanner(ctx, int(banners[0].ID)), bannerrepo.ErrNotFound)
+
+	for i := 0; i < 10; i++ {
+		got, err := bc.GetUserBanner(ctx, banners[0].FeatureID, banners[0].Tags[0])
+		require.NoError(t, err)
+		require.Equal(t, banners[1].ID, got.ID)
+	}
+}
+
+// Сравнение записи баннеров по одному и пачкой через конвейер.
+func BenchmarkCreateBanner(b *testing.B) {
+	ctx := context.Background()
+	bc := newCache(b)
+	banners := testBanners(benchBanners)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, banner := range banners {
+			if err := bc.CreateBanner(ctx, banner); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkCreateBanners(b *testing.B) {
+	ctx := context.Background()
+	bc := newCache(b)
+	banners := testBanners(benchBanners)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := bc.CreateBanners(ctx, banners); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+	"github.com/Leopold1975/banners_control/internal/pkg/breaker"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+)
+
+type Repository interface {
+	CreateBanner(context.Context, models.Banner) (int, error)
+	UpdateBanner(context.Context, models.Banner) (models.Banner, error)
+	DeleteBanner(context.Context, int) (models.Banner, error)
+	GetBannerByFeatureAndTags(context.Context, repo.GetBannerRequest) ([]models.Banner, error)
+	GetBannersByPairs(context.Context, repo.GetBannersByPairsRequest) ([]models.Banner, error)
+	CountBanners(context.Context, repo.GetBannerRequest) (int, error)
+	ExportBanners(context.Context, repo.GetBannerRequest, func(models.Banner) error) error
+	ImportBanners(context.Context, repo.ImportRequest) (repo.ImportResult, error)
+	Shutdown(context.Context) error
+}
+
+// BannersRepo защищает хранилище баннеров автоматическим выключателем:
+// при недоступности БД запросы сразу завершаются ошибкой breaker.ErrUnavailable.
+type BannersRepo struct {
+	repo Repository
+	b    breaker.Breaker
+}
+
+func New(r Repository, cfg config.Breaker, lg logger.Logger) BannersRepo {
+	return BannersRepo{
+		repo: r,
+		b: breaker.New("postgres", cfg, lg, func(err error) bool {
+			return errors.Is(err, repo.ErrNotFound) || errors.Is(err, repo.ErrInvalidFilter) ||
+				errors.Is(err, repo.ErrConflict)
+		}),
+	}
+}
+
+func (br BannersRepo) CreateBanner(ctx context.Context, banner models.Banner) (int, error) {
+	return breaker.Do(br.b, func() (int, error) {
+		return br.repo.CreateBanner(ctx, banner) //nolint:wrapcheck
+	})
+}
+
+func (br BannersRepo) UpdateBanner(ctx context.Context, banner models.Banner) (models.Banner, error) {
+	return breaker.Do(br.b, func() (models.Banner, error) {
+		return br.repo.UpdateBanner(ctx, banner) //nolint:wrapcheck
+	})
+}
+
+func (br BannersRepo) DeleteBanner(ctx context.Context, bannerID int) (models.Banner, error) {
+	return breaker.Do(br.b, func() (models.Banner, error) {
+		return br.repo.DeleteBanner(ctx, bannerID) //nolint:wrapcheck
+	})
+}
+
+func (br BannersRepo) GetBannerByFeatureAndTags(ctx context.Context,
+	req repo.GetBannerRequest,
+) ([]models.Banner, error) {
+	return breaker.Do(br.b, func() ([]models.Banner, error) {
+		return br.repo.GetBannerByFeatureAndTags(ctx, req) //nolint:wrapcheck
+	})
+}
+
+func (br BannersRepo) GetBannersByPairs(ctx context.Context,
+	req repo.GetBannersByPairsRequest,
+) ([]models.Banner, error) {
+	return breaker.Do(br.b, func() ([]models.Banner, error) {
+		return br.repo.GetBannersByPairs(ctx, req) //nolint:wrapcheck
+	})
+}
+
+func (br BannersRepo) CountBanners(ctx context.Context, req repo.GetBannerRequest) (int, error) {
+	return breaker.Do(br.b, func() (int, error) {
+		return br.repo.CountBanners(ctx, req) //nolint:wrapcheck
+	})
+}
+
+// ExportBanners учитывается выключателем как один запрос. Ошибки fn, например
+// разрыв соединения с клиентом, на состояние выключателя не влияют.
+func (br BannersRepo) ExportBanners(ctx context.Context,
+	req repo.GetBannerRequest, fn func(models.Banner) error,
+) error {
+	var fnErr error
+
+	_, err := breaker.Do(br.b, func() (struct{}, error) {
+		err := br.repo.ExportBanners(ctx, req, func(b models.Banner) error {
+			fnErr = fn(b)
+
+			return fnErr
+		})
+		if fnErr != nil {
+			return struct{}{}, nil
+		}
+
+		return struct{}{}, err //nolint:wrapcheck
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+
+	return err
+}
+
+func (br BannersRepo) ImportBanners(ctx context.Context, req repo.ImportRequest) (repo.ImportResult, error) {
+	return breaker.Do(br.b, func() (repo.ImportResult, error) {
+		return br.repo.ImportBanners(ctx, req) //nolint:wrapcheck
+	})
+}
+
+func (br BannersRepo) Shutdown(ctx context.Context) error {
+	return br.repo.Shutdown(ctx) //nolint:wrapcheck
+}
+
+func (br BannersRepo) State() string {
+	return br.b.State()
+}
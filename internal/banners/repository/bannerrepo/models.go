@@ -1,13 +1,112 @@
 package bannerrepo
 
-import "errors"
+import (
+	"errors"
+	"time"
 
-var ErrNotFound = errors.New("banner not found")
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+)
+
+var (
+	ErrNotFound      = errors.New("banner not found")
+	ErrInvalidFilter = errors.New("invalid filter")
+	ErrConflict      = errors.New("banner external key already exists")
+)
+
+// SortField - поле, по которому упорядочивается список баннеров.
+// При равных значениях строки дополнительно упорядочиваются по id.
+type SortField string
+
+const (
+	SortByID        SortField = "id"
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+	SortByFeatureID SortField = "feature_id"
+)
+
+// TagMatch задает, как тэги баннера сопоставляются с тэгами фильтра.
+type TagMatch string
+
+const (
+	// TagMatchAny - у баннера есть хотя бы один из тэгов.
+	TagMatchAny TagMatch = "any"
+	// TagMatchAll - у баннера есть все тэги.
+	TagMatchAll TagMatch = "all"
+)
+
+// Cursor - ключ последней отданной строки. Следующая страница начинается
+// сразу после него. Value - значение поля сортировки (time.Time или int),
+// для сортировки по id не используется.
+type Cursor struct {
+	Value interface{}
+	ID    int64
+}
 
 type GetBannerRequest struct {
-	FeatureID  int
+	// IDs отбирает баннеры с перечисленными идентификаторами.
+	IDs       []int64
+	FeatureID int
+	// FeatureIDs отбирает баннеры любой из перечисленных фич.
+	FeatureIDs []int
 	Tags       []int
+	// TagMatch по умолчанию - TagMatchAny.
+	TagMatch   TagMatch
 	Offset     int
 	Limit      int
 	OnlyActive bool
+	// Active, если задан, отбирает баннеры с указанным флагом активности.
+	Active *bool
+	// Границы дат: From включительно, To не включительно. Нулевое время
+	// означает отсутствие границы.
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	UpdatedFrom time.Time
+	UpdatedTo   time.Time
+	// Search - полнотекстовый поиск по строковым значениям содержимого.
+	Search string
+	// Sort по умолчанию - SortByID.
+	Sort SortField
+	Desc bool
+	// After включает постраничное чтение по ключу вместо Offset.
+	After *Cursor
+	// ContentEq отбирает баннеры, содержимое которых включает все пары ключ-значение.
+	ContentEq map[string]interface{}
+	// ContentPath - предикат JSONPath по содержимому, например `$.url like_regex "old-domain\.com"`.
+	ContentPath string
+}
+
+type FeatureTag struct {
+	FeatureID int
+	TagID     int
+}
+
+// GetBannersByPairsRequest выбирает баннеры сразу для нескольких пар фича-тэг.
+type GetBannersByPairsRequest struct {
+	Pairs      []FeatureTag
+	OnlyActive bool
+}
+
+// ImportBanner - баннер из файла импорта вместе с номером строки для отчета.
+type ImportBanner struct {
+	Line   int
+	Banner models.Banner
+}
+
+type ImportRequest struct {
+	Banners []ImportBanner
+	// Upsert обновляет баннеры с совпадающим ExternalKey. Без него такие
+	// строки считаются конфликтами.
+	Upsert bool
+	// SkipConflicts пропускает конфликтующие строки, иначе импорт
+	// отменяется с ошибкой ErrConflict.
+	SkipConflicts bool
+	// DryRun выполняет импорт и откатывает транзакцию.
+	DryRun bool
+}
+
+type ImportResult struct {
+	Created int
+	Updated int
+	// Conflicts - номера строк, ключ которых уже занят.
+	Conflicts []int
 }
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/pkg/pgtools"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// relayLockKey - ключ advisory-блокировки, под которой публикуются события.
+// События публикует одна реплика за раз, поэтому они уходят в порядке id.
+const relayLockKey = 0x62616e6e657273
+
+// eventPayload - данные события в banner_events и тело запроса вебхука.
+type eventPayload struct {
+	Event      string         `json:"event"`
+	OccurredAt time.Time      `json:"occurred_at"` //nolint:tagliatelle
+	Banner     models.Banner  `json:"banner"`
+	Previous   *models.Banner `json:"previous,omitempty"`
+}
+
+// recordEvent записывает изменение баннера в banner_events и в доставки
+// подписанных вебхуков. Запись идет в транзакции изменения, поэтому событие
+// не теряется при сбое и не публикуется для отмененного изменения. Изменения
+// одного баннера упорядочены блокировкой строки, поэтому id их событий
+// возрастает в порядке фиксации.
+func recordEvent(ctx context.Context, tx pgx.Tx, event string, banner models.Banner,
+	prev *models.Banner,
+) error {
+	payload, err := marshalEvent(event, banner, prev)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO banner_events (banner_id, event, payload) VALUES ($1, $2, $3)`
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", []interface{}{banner.ID, event})
+
+	if _, err := tx.Exec(ctx, query, banner.ID, event, payload); err != nil {
+		return fmt.Errorf("insert banner event error: %w", err)
+	}
+
+	if err := enqueueWebhooks(ctx, tx, event, banner, prev, payload); err != nil {
+		return err
+	}
+
+	if event == models.EventUpdated && !prev.Active && banner.Active {
+		payload, err := marshalEvent(models.EventActivated, banner, prev)
+		if err != nil {
+			return err
+		}
+
+		return enqueueWebhooks(ctx, tx, models.EventActivated, banner, prev, payload)
+	}
+
+	return nil
+}
+
+func marshalEvent(event string, banner models.Banner, prev *models.Banner) ([]byte, error) {
+	payload, err := json.Marshal(eventPayload{
+		Event:      event,
+		OccurredAt: time.Now().UTC(),
+		Banner:     banner,
+		Previous:   prev,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshall event payload error: %w", err)
+	}
+
+	return payload, nil
+}
+
+// enqueueWebhooks записывает событие для вебхуков, подписанных на него и на
+// фичу баннера до или после изменения.
+func enqueueWebhooks(ctx context.Context, tx pgx.Tx, event string, banner models.Banner,
+	prev *models.Banner, payload []byte,
+) error {
+	features := []int{banner.FeatureID}
+	if prev != nil {
+		features = append(features, prev.FeatureID)
+	}
+
+	query := `INSERT INTO webhook_deliveries (webhook_id, event, banner_id, payload)
+		SELECT id, $1::text, $2::bigint, $3::jsonb FROM webhooks
+		WHERE $1::text = ANY(events) AND (cardinality(feature_ids) = 0 OR feature_ids && $4::int[])`
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", []interface{}{event, banner.ID, features})
+
+	if _, err := tx.Exec(ctx, query, event, banner.ID, payload, features); err != nil {
+		return fmt.Errorf("enqueue webhooks error: %w", err)
+	}
+
+	return nil
+}
+
+// RelayEvents передает publish до limit неопубликованных событий в порядке id
+// и отмечает их опубликованными, если publish завершился без ошибки. Пока
+// события публикует другая реплика, возвращает 0. Если отметка не сохранится,
+// события будут опубликованы повторно.
+func (br BannersPostgresRepo) RelayEvents(ctx context.Context, limit int, //nolint:nonamedreturns
+	publish func([]models.BannerEvent) error,
+) (n int, err error) {
+	tx, err := br.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("cannot begin transaction error: %w", err)
+	}
+
+	defer func() {
+		err = pgtools.CommitOrRollback(ctx, tx, err, "relay events")
+	}()
+
+	var locked bool
+
+	if err := tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", relayLockKey).Scan(&locked); err != nil {
+		return 0, fmt.Errorf("lock error: %w", err)
+	}
+
+	if !locked {
+		return 0, nil
+	}
+
+	query := `SELECT id, banner_id, event, created_at, payload FROM banner_events
+		WHERE published_at IS NULL ORDER BY id LIMIT $1`
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", []interface{}{limit})
+
+	rows, err := tx.Query(ctx, query, limit)
+	if err != nil {
+		return 0, fmt.Errorf("query error: %w", err)
+	}
+
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.BannerEvent, error) {
+		var e models.BannerEvent
+
+		err := row.Scan(&e.ID, &e.BannerID, &e.Event, &e.CreatedAt, &e.Data)
+
+		return e, err //nolint:wrapcheck
+	})
+	if err != nil {
+		return 0, fmt.Errorf("scan error: %w", err)
+	}
+
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	if err := publish(events); err != nil {
+		return 0, err
+	}
+
+	ids := make([]int64, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.ID)
+	}
+
+	if _, err := tx.Exec(ctx, "UPDATE banner_events SET published_at = now() WHERE id = ANY($1)", ids); err != nil {
+		return 0, fmt.Errorf("mark published error: %w", err)
+	}
+
+	return len(events), nil
+}
+
+// PurgeEvents удаляет события, опубликованные раньше before, и неопубликованные,
+// созданные раньше before: они копятся, пока публикация выключена.
+func (br BannersPostgresRepo) PurgeEvents(ctx context.Context, before time.Time) (int64, error) {
+	query := "DELETE FROM banner_events WHERE published_at < $1 OR (published_at IS NULL AND created_at < $1)"
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", []interface{}{before})
+
+	tag, err := br.db.Exec(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("purge events error: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
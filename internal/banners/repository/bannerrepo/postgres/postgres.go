@@ -3,14 +3,20 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
 	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
 	"github.com/Leopold1975/banners_control/internal/pkg/config"
 	"github.com/Leopold1975/banners_control/internal/pkg/pgtools"
+	"github.com/Leopold1975/banners_control/pkg/logger"
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib" // driver for migrations
 )
@@ -37,6 +43,22 @@ func New(ctx context.Context, cfg config.PostgresDB) (BannersPostgresRepo, error
 	}, nil
 }
 
+// NewUnchecked создает репозиторий без проверки соединения и применения миграций.
+// Пул соединений подключается к БД лениво, при первом запросе.
+func NewUnchecked(ctx context.Context, cfg config.PostgresDB) (BannersPostgresRepo, error) {
+	connString := "postgres://" + cfg.Username + ":" + cfg.Password + "@" +
+		cfg.Addr + "/" + cfg.DB + "?" + "sslmode=" + cfg.SSLmode + "&pool_max_conns=" + cfg.MaxConns
+
+	db, err := pgtools.Open(ctx, connString)
+	if err != nil {
+		return BannersPostgresRepo{}, fmt.Errorf("open db error: %w", err)
+	}
+
+	return BannersPostgresRepo{
+		db: db,
+	}, nil
+}
+
 func (br BannersPostgresRepo) CreateBanner(ctx context.Context, //nolint:nonamedreturns
 	banner models.Banner,
 ) (id int, err error) {
@@ -68,6 +90,11 @@ func (br BannersPostgresRepo) CreateBanner(ctx context.Context, //nolint:nonamed
 		vals = append(vals, banner.UpdatedAt)
 	}
 
+	if banner.ExternalKey != "" {
+		cols = append(cols, "external_key")
+		vals = append(vals, banner.ExternalKey)
+	}
+
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
 	query, args, err := psql.Insert("banners").
@@ -78,6 +105,8 @@ func (br BannersPostgresRepo) CreateBanner(ctx context.Context, //nolint:nonamed
 		return 0, fmt.Errorf("to sql error: %w", err)
 	}
 
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
 	row := tx.QueryRow(ctx, query, args...)
 
 	err = row.Scan(&id)
@@ -85,18 +114,27 @@ func (br BannersPostgresRepo) CreateBanner(ctx context.Context, //nolint:nonamed
 		return 0, fmt.Errorf("scan error: %w", err)
 	}
 
+	banner.ID = int64(id)
+
+	if err := recordEvent(ctx, tx, models.EventCreated, banner, nil); err != nil {
+		return 0, err
+	}
+
 	return id, nil
 }
 
-func (br BannersPostgresRepo) UpdateBanner(ctx context.Context, banner models.Banner) (err error) {
+// UpdateBanner обновляет баннер и возвращает его состояние до изменения.
+func (br BannersPostgresRepo) UpdateBanner(ctx context.Context, //nolint:nonamedreturns
+	banner models.Banner,
+) (prev models.Banner, err error) {
 	contentJSON, err := json.Marshal(banner.Content)
 	if err != nil {
-		return fmt.Errorf("marshall content error: %w", err)
+		return prev, fmt.Errorf("marshall content error: %w", err)
 	}
 
 	tx, err := br.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("cannot begin transaction error: %w", err)
+		return prev, fmt.Errorf("cannot begin transaction error: %w", err)
 	}
 
 	defer func() {
@@ -105,7 +143,35 @@ func (br BannersPostgresRepo) UpdateBanner(ctx context.Context, banner models.Ba
 
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
-	query, args, err := psql.Update("banners").
+	query, args, err := psql.Select(bannerColumns...).
+		From("banners").
+		Where(squirrel.Eq{"id": banner.ID}).
+		Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		return prev, fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return prev, fmt.Errorf("query error: %w", err)
+	}
+
+	banners, err := scanBanners(rows)
+	rows.Close()
+
+	if err != nil {
+		return prev, err
+	}
+
+	if len(banners) == 0 {
+		return prev, repo.ErrNotFound
+	}
+
+	prev = banners[0]
+
+	query, args, err = psql.Update("banners").
 		Set("feature_id", banner.FeatureID).
 		Set("tag_ids", banner.Tags).
 		Set("is_active", banner.Active).
@@ -113,25 +179,31 @@ func (br BannersPostgresRepo) UpdateBanner(ctx context.Context, banner models.Ba
 		Set("updated_at", banner.UpdatedAt).
 		Where(squirrel.Eq{"id": banner.ID}).ToSql()
 	if err != nil {
-		return fmt.Errorf("to sql error: %w", err)
+		return prev, fmt.Errorf("to sql error: %w", err)
 	}
 
-	ct, err := tx.Exec(ctx, query, args...)
-	if err != nil {
-		return fmt.Errorf("exec error: %w", err)
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return prev, fmt.Errorf("exec error: %w", err)
 	}
 
-	if ct.RowsAffected() == 0 {
-		return repo.ErrNotFound
+	banner.CreatedAt, banner.ExternalKey = prev.CreatedAt, prev.ExternalKey
+
+	if err := recordEvent(ctx, tx, models.EventUpdated, banner, &prev); err != nil {
+		return prev, err
 	}
 
-	return nil
+	return prev, nil
 }
 
-func (br BannersPostgresRepo) DeleteBanner(ctx context.Context, bannerID int) (err error) {
+// DeleteBanner удаляет баннер и возвращает его последнее состояние.
+func (br BannersPostgresRepo) DeleteBanner(ctx context.Context, //nolint:nonamedreturns
+	bannerID int,
+) (banner models.Banner, err error) {
 	tx, err := br.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("cannot begin transaction error: %w", err)
+		return banner, fmt.Errorf("cannot begin transaction error: %w", err)
 	}
 
 	defer func() {
@@ -141,24 +213,38 @@ func (br BannersPostgresRepo) DeleteBanner(ctx context.Context, bannerID int) (e
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 
 	query, args, err := psql.Delete("banners").
-		Where(squirrel.Eq{"id": bannerID}).ToSql()
+		Where(squirrel.Eq{"id": bannerID}).
+		Suffix("RETURNING " + strings.Join(bannerColumns, ", ")).ToSql()
+	if err != nil {
+		return banner, fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	rows, err := tx.Query(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("to sql error: %w", err)
+		return banner, fmt.Errorf("exec error: %w", err)
 	}
 
-	ct, err := tx.Exec(ctx, query, args...)
+	banners, err := scanBanners(rows)
+	rows.Close()
+
 	if err != nil {
-		return fmt.Errorf("exec error: %w", err)
+		return banner, err
 	}
 
-	if ct.RowsAffected() == 0 {
-		return repo.ErrNotFound
+	if len(banners) == 0 {
+		return banner, repo.ErrNotFound
 	}
 
-	return nil
+	if err := recordEvent(ctx, tx, models.EventDeleted, banners[0], nil); err != nil {
+		return banner, err
+	}
+
+	return banners[0], nil
 }
 
-func (br BannersPostgresRepo) GetBannerByFeatureAndTags(ctx context.Context, //nolint:cyclop,nonamedreturns
+func (br BannersPostgresRepo) GetBannerByFeatureAndTags(ctx context.Context, //nolint:nonamedreturns
 	req repo.GetBannerRequest,
 ) (banners []models.Banner, err error) {
 	tx, err := br.db.Begin(ctx)
@@ -171,30 +257,193 @@ func (br BannersPostgresRepo) GetBannerByFeatureAndTags(ctx context.Context, //n
 	}()
 
 	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
-	sb := psql.Select("id", "feature_id", "tag_ids", "is_active", "updated_at", "created_at", "content").
-		From("banners")
+	sb := filterBanners(psql.Select(bannerColumns...).
+		From("banners"), req)
+
+	sb, err = orderBanners(sb, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Offset != 0 {
+		sb = sb.Offset(uint64(req.Offset))
+	}
+
+	if req.Limit != 0 {
+		sb = sb.Limit(uint64(req.Limit))
+	}
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	banners, err = scanBanners(rows)
+	if isInvalidFilter(err) {
+		return nil, fmt.Errorf("%w: %w", repo.ErrInvalidFilter, err)
+	}
+
+	return banners, err
+}
+
+// CountBanners возвращает число баннеров, подходящих под фильтры запроса.
+// Сортировка, курсор, Offset и Limit не учитываются.
+func (br BannersPostgresRepo) CountBanners(ctx context.Context, req repo.GetBannerRequest) (int, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	sb := filterBanners(psql.Select("count(*)").From("banners"), req)
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	var count int
+
+	err = br.db.QueryRow(ctx, query, args...).Scan(&count)
+	if isInvalidFilter(err) {
+		return 0, fmt.Errorf("%w: %w", repo.ErrInvalidFilter, err)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("count error: %w", err)
+	}
+
+	return count, nil
+}
 
+func filterBanners(sb squirrel.SelectBuilder, req repo.GetBannerRequest) squirrel.SelectBuilder { //nolint:cyclop
 	if req.FeatureID != -1 {
 		sb = sb.Where(squirrel.Eq{"feature_id": req.FeatureID})
 	}
 
+	if len(req.IDs) != 0 {
+		sb = sb.Where("id = ANY(?)", req.IDs)
+	}
+
+	if len(req.FeatureIDs) != 0 {
+		sb = sb.Where("feature_id = ANY(?)", req.FeatureIDs)
+	}
+
 	if len(req.Tags) != 0 {
-		sb = sb.Where("(tag_ids && ?)", req.Tags)
+		if req.TagMatch == repo.TagMatchAll {
+			sb = sb.Where("(tag_ids @> ?)", req.Tags)
+		} else {
+			sb = sb.Where("(tag_ids && ?)", req.Tags)
+		}
+	}
+
+	if req.Active != nil {
+		sb = sb.Where(squirrel.Eq{"is_active": *req.Active})
+	}
+
+	if !req.CreatedFrom.IsZero() {
+		sb = sb.Where(squirrel.GtOrEq{"created_at": req.CreatedFrom})
+	}
+
+	if !req.CreatedTo.IsZero() {
+		sb = sb.Where(squirrel.Lt{"created_at": req.CreatedTo})
+	}
+
+	if !req.UpdatedFrom.IsZero() {
+		sb = sb.Where(squirrel.GtOrEq{"updated_at": req.UpdatedFrom})
+	}
+
+	if !req.UpdatedTo.IsZero() {
+		sb = sb.Where(squirrel.Lt{"updated_at": req.UpdatedTo})
+	}
+
+	// Выражение совпадает с индексом banners_content_search_idx.
+	if req.Search != "" {
+		sb = sb.Where("to_tsvector('simple', content) @@ websearch_to_tsquery('simple', ?)", req.Search)
+	}
+
+	// Оба фильтра по содержимому используют GIN-индекс по content.
+	if len(req.ContentEq) != 0 {
+		sb = sb.Where("content @> ?", req.ContentEq)
+	}
+
+	if req.ContentPath != "" {
+		sb = sb.Where("content @@ ?::jsonpath", req.ContentPath)
 	}
 
 	if req.OnlyActive {
-		sb = sb.Where(squirrel.Eq{"is_active": true}).
-			OrderBy("id ASC")
-	} else {
-		sb = sb.OrderBy("id ASC")
+		sb = sb.Where(squirrel.Eq{"is_active": true})
 	}
 
-	if req.Offset != 0 {
-		sb = sb.Offset(uint64(req.Offset))
+	return sb
+}
+
+// orderBanners упорядочивает выборку по (поле сортировки, id) и, если задан
+// курсор, отбрасывает строки до него сравнением кортежей. В отличие от OFFSET
+// такой запрос не просматривает пропущенные строки и не сдвигается, когда
+// во время чтения создаются новые баннеры.
+func orderBanners(sb squirrel.SelectBuilder, req repo.GetBannerRequest) (squirrel.SelectBuilder, error) {
+	dir, op := "ASC", ">"
+	if req.Desc {
+		dir, op = "DESC", "<"
 	}
 
-	if req.Limit != 0 {
-		sb = sb.Limit(uint64(req.Limit))
+	switch req.Sort {
+	case "", repo.SortByID:
+		if req.After != nil {
+			sb = sb.Where("id "+op+" ?", req.After.ID)
+		}
+
+		return sb.OrderBy("id " + dir), nil
+	case repo.SortByCreatedAt, repo.SortByUpdatedAt, repo.SortByFeatureID:
+		col := string(req.Sort)
+
+		if req.After != nil {
+			sb = sb.Where(fmt.Sprintf("(%s, id) %s (?, ?)", col, op), req.After.Value, req.After.ID)
+		}
+
+		return sb.OrderBy(col+" "+dir, "id "+dir), nil
+	default:
+		return sb, fmt.Errorf("%w: unknown sort field %q", repo.ErrInvalidFilter, req.Sort)
+	}
+}
+
+// isInvalidFilter сообщает, что БД отвергла выражение JSONPath из фильтра.
+func isInvalidFilter(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) &&
+		(pgErr.Code == pgerrcode.SyntaxError || pgErr.Code == pgerrcode.InvalidRegularExpression)
+}
+
+// GetBannersByPairs выбирает одним запросом баннеры всех фич из запроса,
+// у которых есть хотя бы один из тэгов запроса. Сопоставление с конкретными
+// парами фича-тэг остается вызывающему.
+func (br BannersPostgresRepo) GetBannersByPairs(ctx context.Context,
+	req repo.GetBannersByPairsRequest,
+) ([]models.Banner, error) {
+	features := make([]int, 0, len(req.Pairs))
+	tags := make([]int, 0, len(req.Pairs))
+
+	for _, p := range req.Pairs {
+		features = append(features, p.FeatureID)
+		tags = append(tags, p.TagID)
+	}
+
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	sb := psql.Select(bannerColumns...).
+		From("banners").
+		Where("feature_id = ANY(?)", features).
+		Where("(tag_ids && ?)", tags).
+		OrderBy("id ASC")
+
+	if req.OnlyActive {
+		sb = sb.Where(squirrel.Eq{"is_active": true})
 	}
 
 	query, args, err := sb.ToSql()
@@ -202,36 +451,51 @@ func (br BannersPostgresRepo) GetBannerByFeatureAndTags(ctx context.Context, //n
 		return nil, fmt.Errorf("to sql error: %w", err)
 	}
 
-	rows, err := tx.Query(ctx, query, args...)
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	rows, err := br.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query error: %w", err)
 	}
 	defer rows.Close()
 
-	banners = make([]models.Banner, 0, 10) //nolint:gomnd
+	return scanBanners(rows)
+}
 
-	for rows.Next() {
-		var b models.Banner
+// bannerColumns - столбцы в порядке, который ожидает scanBanner.
+var bannerColumns = []string{ //nolint:gochecknoglobals
+	"id", "feature_id", "tag_ids", "is_active", "updated_at", "created_at", "content",
+	"coalesce(external_key, '')",
+}
 
-		var contentJSON string
+func scanBanner(rows pgx.Rows) (models.Banner, error) {
+	var b models.Banner
 
-		err = rows.Scan(&b.ID, &b.FeatureID, &b.Tags, &b.Active, &b.UpdatedAt, &b.CreatedAt, &contentJSON)
-		if err != nil {
-			return nil, fmt.Errorf("scan error %w", err)
-		}
+	// content хранится в jsonb, pgx разбирает его сразу в отображение.
+	err := rows.Scan(&b.ID, &b.FeatureID, &b.Tags, &b.Active, &b.UpdatedAt, &b.CreatedAt, &b.Content, &b.ExternalKey)
+	if err != nil {
+		return models.Banner{}, fmt.Errorf("scan error %w", err)
+	}
+
+	return b, nil
+}
 
-		tmp := make(map[string]interface{})
+func scanBanners(rows pgx.Rows) ([]models.Banner, error) {
+	banners := make([]models.Banner, 0, 10) //nolint:gomnd
 
-		err = json.Unmarshal([]byte(contentJSON), &tmp)
+	for rows.Next() {
+		b, err := scanBanner(rows)
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal error %w", err)
+			return nil, err
 		}
 
-		b.Content = tmp
-
 		banners = append(banners, b)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error %w", err)
+	}
+
 	return banners, nil
 }
 
@@ -250,3 +514,19 @@ func (br BannersPostgresRepo) Shutdown(ctx context.Context) error {
 		return nil
 	}
 }
+
+func (br BannersPostgresRepo) Stat() *pgxpool.Stat {
+	return br.db.Stat()
+}
+
+func (br BannersPostgresRepo) Ping(ctx context.Context) error {
+	if err := br.db.Ping(ctx); err != nil {
+		return fmt.Errorf("ping error: %w", err)
+	}
+
+	return nil
+}
+
+func (br BannersPostgresRepo) MigrationVersion(ctx context.Context) (int64, error) {
+	return pgtools.MigrationVersion(ctx, br.db) //nolint:wrapcheck
+}
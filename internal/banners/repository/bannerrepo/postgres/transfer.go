@@ -0,0 +1,245 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+	"github.com/Leopold1975/banners_control/internal/pkg/pgtools"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+var errDryRun = errors.New("dry run")
+
+// ExportBanners передает fn баннеры, подходящие под фильтры запроса, по мере
+// чтения из БД, не собирая всю выборку в памяти. Ошибка fn прерывает чтение.
+func (br BannersPostgresRepo) ExportBanners(ctx context.Context,
+	req repo.GetBannerRequest, fn func(models.Banner) error,
+) error {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	sb := filterBanners(psql.Select(bannerColumns...).From("banners"), req)
+
+	sb, err := orderBanners(sb, req)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	rows, err := br.db.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		b, err := scanBanner(rows)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		if isInvalidFilter(err) {
+			return fmt.Errorf("%w: %w", repo.ErrInvalidFilter, err)
+		}
+
+		return fmt.Errorf("rows error %w", err)
+	}
+
+	return nil
+}
+
+// ImportBanners загружает баннеры через COPY во временную таблицу и переносит
+// их в banners одним запросом в той же транзакции. Импорт применяется целиком
+// или не применяется вовсе. Созданные и обновленные баннеры записываются
+// в banner_events и доставки вебхуков в той же транзакции.
+func (br BannersPostgresRepo) ImportBanners(ctx context.Context, //nolint:nonamedreturns
+	req repo.ImportRequest,
+) (res repo.ImportResult, err error) {
+	tx, err := br.db.Begin(ctx)
+	if err != nil {
+		return res, fmt.Errorf("cannot begin transaction error: %w", err)
+	}
+
+	defer func() {
+		if req.DryRun && err == nil {
+			err = errDryRun
+		}
+
+		err = pgtools.CommitOrRollback(ctx, tx, err, "import")
+		if errors.Is(err, errDryRun) {
+			err = nil
+		}
+	}()
+
+	_, err = tx.Exec(ctx, `CREATE TEMP TABLE banners_import (
+		line int, feature_id int, tag_ids int[], is_active boolean, content jsonb,
+		external_key text, created_at timestamptz, updated_at timestamptz
+	) ON COMMIT DROP`)
+	if err != nil {
+		return res, fmt.Errorf("create temp table error: %w", err)
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"banners_import"},
+		[]string{"line", "feature_id", "tag_ids", "is_active", "content", "external_key", "created_at", "updated_at"},
+		pgx.CopyFromSlice(len(req.Banners), func(i int) ([]interface{}, error) {
+			ib := req.Banners[i]
+
+			var key *string
+			if ib.Banner.ExternalKey != "" {
+				key = &ib.Banner.ExternalKey
+			}
+
+			return []interface{}{
+				ib.Line, ib.Banner.FeatureID, ib.Banner.Tags, ib.Banner.Active, ib.Banner.Content,
+				key, ib.Banner.CreatedAt, ib.Banner.UpdatedAt,
+			}, nil
+		}))
+	if err != nil {
+		return res, fmt.Errorf("copy error: %w", err)
+	}
+
+	if !req.Upsert {
+		res.Conflicts, err = importConflicts(ctx, tx)
+		if err != nil {
+			return res, err
+		}
+
+		if len(res.Conflicts) != 0 {
+			if !req.SkipConflicts {
+				return res, repo.ErrConflict
+			}
+
+			if _, err = tx.Exec(ctx, "DELETE FROM banners_import WHERE line = ANY($1)", res.Conflicts); err != nil {
+				return res, fmt.Errorf("delete conflicts error: %w", err)
+			}
+		}
+	}
+
+	var previous map[string]models.Banner
+
+	if req.Upsert {
+		if previous, err = importPrevious(ctx, tx); err != nil {
+			return res, err
+		}
+	}
+
+	query := `INSERT INTO banners (feature_id, tag_ids, is_active, content, external_key, created_at, updated_at)
+		SELECT feature_id, tag_ids, is_active, content, external_key, created_at, updated_at
+		FROM banners_import ORDER BY line`
+	if req.Upsert {
+		query += `
+		ON CONFLICT (external_key) DO UPDATE SET
+			feature_id = EXCLUDED.feature_id,
+			tag_ids = EXCLUDED.tag_ids,
+			is_active = EXCLUDED.is_active,
+			content = EXCLUDED.content,
+			updated_at = EXCLUDED.updated_at`
+	}
+
+	// xmax новой строки равен нулю, у обновленной - идентификатору транзакции.
+	query += " RETURNING " + strings.Join(bannerColumns, ", ") + ", xmax = 0"
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "rows", len(req.Banners))
+
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return res, fmt.Errorf("insert error: %w", err)
+	}
+
+	type imported struct {
+		banner   models.Banner
+		inserted bool
+	}
+
+	// Строки читаются целиком до записи событий: запросы в транзакции
+	// выполняются по одному.
+	changed, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (imported, error) {
+		var i imported
+
+		b := &i.banner
+		err := row.Scan(&b.ID, &b.FeatureID, &b.Tags, &b.Active, &b.UpdatedAt, &b.CreatedAt, &b.Content,
+			&b.ExternalKey, &i.inserted)
+
+		return i, err //nolint:wrapcheck
+	})
+	if err != nil {
+		return res, fmt.Errorf("insert error: %w", err)
+	}
+
+	for _, i := range changed {
+		if i.inserted {
+			res.Created++
+
+			err = recordEvent(ctx, tx, models.EventCreated, i.banner, nil)
+		} else {
+			res.Updated++
+
+			prev := previous[i.banner.ExternalKey]
+			err = recordEvent(ctx, tx, models.EventUpdated, i.banner, &prev)
+		}
+
+		if err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// importPrevious блокирует и возвращает баннеры, которые обновит импорт, по
+// external_key.
+func importPrevious(ctx context.Context, tx pgx.Tx) (map[string]models.Banner, error) {
+	query := "SELECT " + strings.Join(bannerColumns, ", ") + ` FROM banners
+		WHERE external_key IN (SELECT external_key FROM banners_import) FOR UPDATE`
+
+	logger.FromContext(ctx).Debugw("query", "sql", query)
+
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("find previous error: %w", err)
+	}
+	defer rows.Close()
+
+	banners, err := scanBanners(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make(map[string]models.Banner, len(banners))
+	for _, b := range banners {
+		previous[b.ExternalKey] = b
+	}
+
+	return previous, nil
+}
+
+func importConflicts(ctx context.Context, tx pgx.Tx) ([]int, error) {
+	rows, err := tx.Query(ctx, `SELECT i.line FROM banners_import i
+		JOIN banners b ON b.external_key = i.external_key ORDER BY i.line`)
+	if err != nil {
+		return nil, fmt.Errorf("find conflicts error: %w", err)
+	}
+
+	lines, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("find conflicts error: %w", err)
+	}
+
+	return lines, nil
+}
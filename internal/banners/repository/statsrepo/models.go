@@ -0,0 +1,10 @@
+package statsrepo
+
+import "time"
+
+type GetStatsRequest struct {
+	BannerID int64
+	// From и To - границы периода [From, To), округленные до часа.
+	From time.Time
+	To   time.Time
+}
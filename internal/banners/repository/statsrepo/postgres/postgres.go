@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/statsrepo"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/internal/pkg/pgtools"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StatsPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+// New подключается к БД. Миграции применяет репозиторий баннеров.
+func New(ctx context.Context, cfg config.PostgresDB) (StatsPostgresRepo, error) {
+	db, err := pgtools.Connect(ctx, connString(cfg))
+	if err != nil {
+		return StatsPostgresRepo{}, fmt.Errorf("connect to db error: %w", err)
+	}
+
+	return StatsPostgresRepo{
+		db: db,
+	}, nil
+}
+
+// NewUnchecked создает репозиторий без проверки соединения.
+// Пул соединений подключается к БД лениво, при первом запросе.
+func NewUnchecked(ctx context.Context, cfg config.PostgresDB) (StatsPostgresRepo, error) {
+	db, err := pgtools.Open(ctx, connString(cfg))
+	if err != nil {
+		return StatsPostgresRepo{}, fmt.Errorf("open db error: %w", err)
+	}
+
+	return StatsPostgresRepo{
+		db: db,
+	}, nil
+}
+
+func connString(cfg config.PostgresDB) string {
+	return "postgres://" + cfg.Username + ":" + cfg.Password + "@" +
+		cfg.Addr + "/" + cfg.DB + "?" + "sslmode=" + cfg.SSLmode + "&pool_max_conns=" + cfg.MaxConns
+}
+
+// AddCounters прибавляет счетчики к сохраненным. Баннер, тэг и час
+// в counters не должны повторяться.
+func (sr StatsPostgresRepo) AddCounters(ctx context.Context, counters []models.StatsCounter) error {
+	if len(counters) == 0 {
+		return nil
+	}
+
+	var (
+		bannerIDs   = make([]int64, 0, len(counters))
+		tagIDs      = make([]int, 0, len(counters))
+		hours       = make([]time.Time, 0, len(counters))
+		impressions = make([]int64, 0, len(counters))
+		clicks      = make([]int64, 0, len(counters))
+	)
+
+	for _, c := range counters {
+		bannerIDs = append(bannerIDs, c.BannerID)
+		tagIDs = append(tagIDs, c.TagID)
+		hours = append(hours, c.Hour)
+		impressions = append(impressions, c.Impressions)
+		clicks = append(clicks, c.Clicks)
+	}
+
+	query := `INSERT INTO banner_stats (banner_id, tag_id, hour, impressions, clicks)
+		SELECT * FROM unnest($1::bigint[], $2::int[], $3::timestamptz[], $4::bigint[], $5::bigint[])
+		ON CONFLICT (banner_id, hour, tag_id) DO UPDATE SET
+			impressions = banner_stats.impressions + excluded.impressions,
+			clicks = banner_stats.clicks + excluded.clicks`
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "rows", len(counters))
+
+	if _, err := sr.db.Exec(ctx, query, bannerIDs, tagIDs, hours, impressions, clicks); err != nil {
+		return fmt.Errorf("insert error: %w", err)
+	}
+
+	return nil
+}
+
+// GetTagStats возвращает показы и клики баннера по тэгам в порядке тэгов.
+func (sr StatsPostgresRepo) GetTagStats(ctx context.Context, req statsrepo.GetStatsRequest) ([]models.TagStats, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	query, args, err := psql.Select("tag_id", "sum(impressions)::bigint", "sum(clicks)::bigint").
+		From("banner_stats").
+		Where(squirrel.Eq{"banner_id": req.BannerID}).
+		Where(squirrel.GtOrEq{"hour": req.From}).
+		Where(squirrel.Lt{"hour": req.To}).
+		GroupBy("tag_id").
+		OrderBy("tag_id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	rows, err := sr.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+
+	stats, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.TagStats, error) {
+		var t models.TagStats
+
+		err := row.Scan(&t.TagID, &t.Impressions, &t.Clicks)
+
+		return t, err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan error: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (sr StatsPostgresRepo) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		sr.db.Close()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context error: %w", ctx.Err())
+	case <-done:
+		return nil
+	}
+}
+
+func (sr StatsPostgresRepo) Stat() *pgxpool.Stat {
+	return sr.db.Stat()
+}
@@ -0,0 +1,180 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/internal/pkg/redistools"
+	"github.com/redis/go-redis/v9"
+)
+
+// Счетчики часа хранятся в хэше с полями <banner_id>:<tag_id>:i для показов
+// и <banner_id>:<tag_id>:c для кликов, а часы с непереданными счетчиками -
+// в отдельном множестве. Ключи содержат хэш-тэг, поэтому в режиме кластера
+// попадают в один слот и обрабатываются одним скриптом.
+const (
+	hoursKey = "{banner_stats}:hours"
+
+	impressionsSuffix = ":i"
+	clicksSuffix      = ":c"
+)
+
+// takeScript забирает счетчики часа атомарно: прибавленные после него
+// попадут в следующий вызов.
+var takeScript = redis.NewScript(`
+local data = redis.call("HGETALL", KEYS[2])
+redis.call("DEL", KEYS[2])
+redis.call("SREM", KEYS[1], ARGV[1])
+return data`)
+
+func hourKey(hour string) string {
+	return "{banner_stats}:hour:" + hour
+}
+
+type StatsRedisRepo struct {
+	rdb redis.UniversalClient
+}
+
+func New(ctx context.Context, cfg config.RedisCache) (StatsRedisRepo, error) {
+	sr, err := NewUnchecked(cfg)
+	if err != nil {
+		return StatsRedisRepo{}, err
+	}
+
+	if err := redistools.Connect(ctx, sr.rdb); err != nil {
+		return StatsRedisRepo{}, fmt.Errorf("connect error: %w", err)
+	}
+
+	return sr, nil
+}
+
+// NewUnchecked создает хранилище без проверки соединения: счетчики, которые
+// не удалось добавить, остаются у вызывающего.
+func NewUnchecked(cfg config.RedisCache) (StatsRedisRepo, error) {
+	rdb, err := redistools.NewClient(cfg)
+	if err != nil {
+		return StatsRedisRepo{}, fmt.Errorf("create redis client error: %w", err)
+	}
+
+	return StatsRedisRepo{rdb: rdb}, nil
+}
+
+// AddCounters прибавляет счетчики к общим счетчикам реплик.
+func (sr StatsRedisRepo) AddCounters(ctx context.Context, counters []models.StatsCounter) error {
+	if len(counters) == 0 {
+		return nil
+	}
+
+	pipe := sr.rdb.TxPipeline()
+
+	for _, c := range counters {
+		hour := strconv.FormatInt(c.Hour.Unix(), 10)
+		field := strconv.FormatInt(c.BannerID, 10) + ":" + strconv.Itoa(c.TagID)
+
+		if c.Impressions != 0 {
+			pipe.HIncrBy(ctx, hourKey(hour), field+impressionsSuffix, c.Impressions)
+		}
+
+		if c.Clicks != 0 {
+			pipe.HIncrBy(ctx, hourKey(hour), field+clicksSuffix, c.Clicks)
+		}
+
+		pipe.SAdd(ctx, hoursKey, hour)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("incr counters error: %w", err)
+	}
+
+	return nil
+}
+
+// TakeCounters забирает накопленные счетчики. Каждый час забирается
+// атомарно, поэтому реплики могут забирать счетчики одновременно.
+func (sr StatsRedisRepo) TakeCounters(ctx context.Context) ([]models.StatsCounter, error) {
+	hours, err := sr.rdb.SMembers(ctx, hoursKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get hours error: %w", err)
+	}
+
+	var counters []models.StatsCounter
+
+	for _, hour := range hours {
+		ts, err := strconv.ParseInt(hour, 10, 64)
+		if err != nil {
+			return counters, fmt.Errorf("parse hour %q error: %w", hour, err)
+		}
+
+		data, err := takeScript.Run(ctx, sr.rdb, []string{hoursKey, hourKey(hour)}, hour).StringSlice()
+		if err != nil {
+			return counters, fmt.Errorf("take counters error: %w", err)
+		}
+
+		counters = append(counters, parseCounters(time.Unix(ts, 0).UTC(), data)...)
+	}
+
+	return counters, nil
+}
+
+// parseCounters собирает счетчики часа из пар поле-значение HGETALL.
+// Поля неизвестного формата пропускаются.
+func parseCounters(hour time.Time, data []string) []models.StatsCounter {
+	type key struct {
+		bannerID int64
+		tagID    int
+	}
+
+	idx := make(map[key]int)
+	counters := make([]models.StatsCounter, 0, len(data)/4) //nolint:gomnd
+
+	for i := 0; i+1 < len(data); i += 2 {
+		field, isClick := strings.CutSuffix(data[i], clicksSuffix)
+		if !isClick {
+			var ok bool
+
+			if field, ok = strings.CutSuffix(data[i], impressionsSuffix); !ok {
+				continue
+			}
+		}
+
+		bannerStr, tagStr, ok := strings.Cut(field, ":")
+		if !ok {
+			continue
+		}
+
+		bannerID, err1 := strconv.ParseInt(bannerStr, 10, 64)
+		tagID, err2 := strconv.Atoi(tagStr)
+		n, err3 := strconv.ParseInt(data[i+1], 10, 64)
+
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+
+		k := key{bannerID: bannerID, tagID: tagID}
+
+		j, ok := idx[k]
+		if !ok {
+			j = len(counters)
+			idx[k] = j
+
+			counters = append(counters, models.StatsCounter{BannerID: bannerID, TagID: tagID, Hour: hour}) //nolint:exhaustruct
+		}
+
+		if isClick {
+			counters[j].Clicks += n
+		} else {
+			counters[j].Impressions += n
+		}
+	}
+
+	return counters
+}
+
+func (sr StatsRedisRepo) Shutdown(_ context.Context) error {
+	return sr.rdb.Close() //nolint:wrapcheck
+}
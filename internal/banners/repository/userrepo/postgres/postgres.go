@@ -37,6 +37,22 @@ func New(ctx context.Context, cfg config.PostgresDB) (UsersPostgresRepo, error)
 	}, nil
 }
 
+// NewUnchecked создает репозиторий без проверки соединения и применения миграций.
+// Пул соединений подключается к БД лениво, при первом запросе.
+func NewUnchecked(ctx context.Context, cfg config.PostgresDB) (UsersPostgresRepo, error) {
+	connString := "postgres://" + cfg.Username + ":" + cfg.Password + "@" +
+		cfg.Addr + "/" + cfg.DB + "?" + "sslmode=" + cfg.SSLmode + "&pool_max_conns=" + cfg.MaxConns
+
+	db, err := pgtools.Open(ctx, connString)
+	if err != nil {
+		return UsersPostgresRepo{}, fmt.Errorf("open db error: %w", err)
+	}
+
+	return UsersPostgresRepo{
+		db: db,
+	}, nil
+}
+
 func (ur UsersPostgresRepo) CreateUser(ctx context.Context, u models.User) error {
 	tx, err := ur.db.Begin(ctx)
 	if err != nil {
@@ -105,3 +121,7 @@ func (ur UsersPostgresRepo) GetUser(ctx context.Context, username string) (model
 
 	return u, nil
 }
+
+func (ur UsersPostgresRepo) Stat() *pgxpool.Stat {
+	return ur.db.Stat()
+}
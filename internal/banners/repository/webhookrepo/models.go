@@ -0,0 +1,24 @@
+package webhookrepo
+
+import (
+	"errors"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+)
+
+var ErrNotFound = errors.New("webhook not found")
+
+type GetDeliveriesRequest struct {
+	WebhookID int
+	// Status, если задан, отбирает доставки с этим статусом.
+	Status string
+	Limit  int
+	Offset int
+}
+
+// Task - доставка, захваченная для отправки, вместе с адресом и секретом вебхука.
+type Task struct {
+	Delivery models.WebhookDelivery
+	URL      string
+	Secret   string
+}
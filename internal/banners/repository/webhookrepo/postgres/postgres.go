@@ -0,0 +1,374 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/webhookrepo"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/internal/pkg/pgtools"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhooksPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+// New подключается к БД. Миграции применяет репозиторий баннеров.
+func New(ctx context.Context, cfg config.PostgresDB) (WebhooksPostgresRepo, error) {
+	db, err := pgtools.Connect(ctx, connString(cfg))
+	if err != nil {
+		return WebhooksPostgresRepo{}, fmt.Errorf("connect to db error: %w", err)
+	}
+
+	return WebhooksPostgresRepo{
+		db: db,
+	}, nil
+}
+
+// NewUnchecked создает репозиторий без проверки соединения.
+// Пул соединений подключается к БД лениво, при первом запросе.
+func NewUnchecked(ctx context.Context, cfg config.PostgresDB) (WebhooksPostgresRepo, error) {
+	db, err := pgtools.Open(ctx, connString(cfg))
+	if err != nil {
+		return WebhooksPostgresRepo{}, fmt.Errorf("open db error: %w", err)
+	}
+
+	return WebhooksPostgresRepo{
+		db: db,
+	}, nil
+}
+
+func connString(cfg config.PostgresDB) string {
+	return "postgres://" + cfg.Username + ":" + cfg.Password + "@" +
+		cfg.Addr + "/" + cfg.DB + "?" + "sslmode=" + cfg.SSLmode + "&pool_max_conns=" + cfg.MaxConns
+}
+
+func (wr WebhooksPostgresRepo) CreateWebhook(ctx context.Context, w models.Webhook) (models.Webhook, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	query, args, err := psql.Insert("webhooks").
+		Columns("url", "secret", "events", "feature_ids").
+		Values(w.URL, w.Secret, w.Events, w.FeatureIDs).
+		Suffix("RETURNING id, created_at").ToSql()
+	if err != nil {
+		return w, fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query)
+
+	if err := wr.db.QueryRow(ctx, query, args...).Scan(&w.ID, &w.CreatedAt); err != nil {
+		return w, fmt.Errorf("insert error: %w", err)
+	}
+
+	return w, nil
+}
+
+// GetWebhooks возвращает вебхуки без секретов.
+func (wr WebhooksPostgresRepo) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	return wr.getWebhooks(ctx, nil)
+}
+
+// GetWebhook возвращает вебхук без секрета.
+func (wr WebhooksPostgresRepo) GetWebhook(ctx context.Context, id int) (models.Webhook, error) {
+	webhooks, err := wr.getWebhooks(ctx, squirrel.Eq{"id": id})
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	if len(webhooks) == 0 {
+		return models.Webhook{}, webhookrepo.ErrNotFound
+	}
+
+	return webhooks[0], nil
+}
+
+func (wr WebhooksPostgresRepo) getWebhooks(ctx context.Context, where interface{}) ([]models.Webhook, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	sb := psql.Select("id", "url", "events", "feature_ids", "created_at").
+		From("webhooks").
+		OrderBy("id ASC")
+
+	if where != nil {
+		sb = sb.Where(where)
+	}
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	rows, err := wr.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+
+	webhooks, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Webhook, error) {
+		var w models.Webhook
+
+		err := row.Scan(&w.ID, &w.URL, &w.Events, &w.FeatureIDs, &w.CreatedAt)
+
+		return w, err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan error: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook удаляет вебхук вместе с журналом его доставок.
+func (wr WebhooksPostgresRepo) DeleteWebhook(ctx context.Context, id int) error {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	query, args, err := psql.Delete("webhooks").Where(squirrel.Eq{"id": id}).ToSql()
+	if err != nil {
+		return fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	tag, err := wr.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("exec error: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return webhookrepo.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetDeliveries возвращает журнал доставок вебхука, новые первыми.
+func (wr WebhooksPostgresRepo) GetDeliveries(ctx context.Context,
+	req webhookrepo.GetDeliveriesRequest,
+) ([]models.WebhookDelivery, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	sb := psql.Select(deliveryColumns("")...).
+		From("webhook_deliveries").
+		Where(squirrel.Eq{"webhook_id": req.WebhookID}).
+		OrderBy("id DESC")
+
+	if req.Status != "" {
+		sb = sb.Where(squirrel.Eq{"status": req.Status})
+	}
+
+	if req.Offset != 0 {
+		sb = sb.Offset(uint64(req.Offset))
+	}
+
+	if req.Limit != 0 {
+		sb = sb.Limit(uint64(req.Limit))
+	}
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	rows, err := wr.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+
+	deliveries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.WebhookDelivery, error) {
+		return scanDelivery(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan error: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ClaimDeliveries захватывает до limit доставок, время попытки которых наступило:
+// увеличивает число попыток и откладывает следующую на lease, чтобы доставку
+// не взяла другая реплика. Если реплика не сохранит результат, доставка
+// повторится после lease. Доставка не захватывается, пока у того же вебхука
+// есть более ранняя недоставленная для того же баннера, так события одного
+// баннера приходят по порядку.
+func (wr WebhooksPostgresRepo) ClaimDeliveries(ctx context.Context, limit int,
+	lease time.Duration,
+) ([]webhookrepo.Task, error) {
+	query := `WITH claimed AS (
+			SELECT d.id FROM webhook_deliveries d
+			WHERE d.status = 'pending' AND d.next_attempt_at <= now()
+				AND NOT EXISTS (
+					SELECT 1 FROM webhook_deliveries p
+					WHERE p.webhook_id = d.webhook_id AND p.banner_id = d.banner_id
+						AND p.status = 'pending' AND p.id < d.id
+				)
+			ORDER BY d.id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET attempts = d.attempts + 1, next_attempt_at = now() + make_interval(secs => $2)
+		FROM claimed, webhooks w
+		WHERE d.id = claimed.id AND w.id = d.webhook_id
+		RETURNING ` + strings.Join(deliveryColumns("d."), ", ") + `, w.url, w.secret`
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", []interface{}{limit, lease.Seconds()})
+
+	rows, err := wr.db.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+
+	tasks, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (webhookrepo.Task, error) {
+		var t webhookrepo.Task
+
+		d, err := scanDelivery(row, &t.URL, &t.Secret)
+		t.Delivery = d
+
+		return t, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan error: %w", err)
+	}
+
+	slices.SortFunc(tasks, func(a, b webhookrepo.Task) int {
+		return cmp.Compare(a.Delivery.ID, b.Delivery.ID)
+	})
+
+	return tasks, nil
+}
+
+// SaveDelivery сохраняет результат попытки доставки.
+func (wr WebhooksPostgresRepo) SaveDelivery(ctx context.Context, d models.WebhookDelivery) error {
+	var deliveredAt *time.Time
+	if !d.DeliveredAt.IsZero() {
+		deliveredAt = &d.DeliveredAt
+	}
+
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	query, args, err := psql.Update("webhook_deliveries").
+		Set("status", d.Status).
+		Set("next_attempt_at", d.NextAttemptAt).
+		Set("response_code", squirrel.Expr("nullif(?::int, 0)", d.ResponseCode)).
+		Set("last_error", squirrel.Expr("nullif(?, '')", d.LastError)).
+		Set("delivered_at", deliveredAt).
+		Where(squirrel.Eq{"id": d.ID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	if _, err := wr.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("exec error: %w", err)
+	}
+
+	return nil
+}
+
+// ReleaseDelivery возвращает захваченную доставку в очередь: отменяет
+// попытку, засчитанную ClaimDeliveries, и разрешает повторить ее сразу.
+func (wr WebhooksPostgresRepo) ReleaseDelivery(ctx context.Context, id int64) error {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	query, args, err := psql.Update("webhook_deliveries").
+		Set("attempts", squirrel.Expr("greatest(attempts - 1, 0)")).
+		Set("next_attempt_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id, "status": models.DeliveryPending}).ToSql()
+	if err != nil {
+		return fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	if _, err := wr.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("exec error: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeDeliveries удаляет завершенные доставки, созданные раньше before.
+func (wr WebhooksPostgresRepo) PurgeDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	psql := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+
+	query, args, err := psql.Delete("webhook_deliveries").
+		Where(squirrel.NotEq{"status": models.DeliveryPending}).
+		Where(squirrel.Lt{"created_at": before}).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("to sql error: %w", err)
+	}
+
+	logger.FromContext(ctx).Debugw("query", "sql", query, "args", args)
+
+	tag, err := wr.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("exec error: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (wr WebhooksPostgresRepo) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+
+	go func() {
+		wr.db.Close()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context error: %w", ctx.Err())
+	case <-done:
+		return nil
+	}
+}
+
+func (wr WebhooksPostgresRepo) Stat() *pgxpool.Stat {
+	return wr.db.Stat()
+}
+
+// deliveryColumns возвращает столбцы доставки с префиксом таблицы prefix
+// в порядке, который ожидает scanDelivery.
+func deliveryColumns(prefix string) []string {
+	return []string{
+		prefix + "id", prefix + "webhook_id", prefix + "event", prefix + "banner_id", prefix + "payload",
+		prefix + "status", prefix + "attempts", prefix + "next_attempt_at",
+		"coalesce(" + prefix + "response_code, 0)", "coalesce(" + prefix + "last_error, '')",
+		prefix + "created_at", prefix + "delivered_at",
+	}
+}
+
+// scanDelivery читает доставку и следующие за ней столбцы в extra.
+func scanDelivery(row pgx.Row, extra ...interface{}) (models.WebhookDelivery, error) {
+	var (
+		d           models.WebhookDelivery
+		deliveredAt *time.Time
+	)
+
+	dest := append([]interface{}{
+		&d.ID, &d.WebhookID, &d.Event, &d.BannerID, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&d.ResponseCode, &d.LastError, &d.CreatedAt, &deliveredAt,
+	}, extra...)
+
+	if err := row.Scan(dest...); err != nil {
+		return d, fmt.Errorf("scan error %w", err)
+	}
+
+	if deliveredAt != nil {
+		d.DeliveredAt = *deliveredAt
+	}
+
+	return d, nil
+}
@@ -81,6 +81,20 @@ func (as *AuthService) Auth(token string) (bool, error) {
 	return role == adminRole, nil
 }
 
+// Principal возвращает имя пользователя из токена, а для токенов без имени - роль.
+func (as *AuthService) Principal(token string) (string, error) {
+	claims, err := jwtauth.ParseToken(token, as.cfg.Secret)
+	if err != nil {
+		return "", fmt.Errorf("parse token error: %w", err)
+	}
+
+	if claims.Subject == "" {
+		return claims.Role, nil
+	}
+
+	return claims.Subject, nil
+}
+
 func (as *AuthService) Login(ctx context.Context, username, password string) (string, error) {
 	u, err := as.userRepo.GetUser(ctx, username)
 	if err != nil {
@@ -0,0 +1,88 @@
+package bannerservice
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+)
+
+// cursor - содержимое непрозрачного курсора. Порядок сортировки сохраняется
+// вместе с ключом, чтобы курсор нельзя было применить к другому порядку.
+type cursor struct {
+	Sort  repo.SortField  `json:"s"`
+	Desc  bool            `json:"d,omitempty"`
+	Value json.RawMessage `json:"v,omitempty"`
+	ID    int64           `json:"id"`
+}
+
+func encodeCursor(sort repo.SortField, desc bool, b models.Banner) (string, error) {
+	c := cursor{Sort: sort, Desc: desc, Value: nil, ID: b.ID}
+
+	var v interface{}
+
+	switch sort {
+	case repo.SortByCreatedAt:
+		v = b.CreatedAt
+	case repo.SortByUpdatedAt:
+		v = b.UpdatedAt
+	case repo.SortByFeatureID:
+		v = b.FeatureID
+	case repo.SortByID:
+	}
+
+	if v != nil {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("marshal cursor value error: %w", err)
+		}
+
+		c.Value = raw
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("marshal cursor error: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(s string, sort repo.SortField, desc bool) (*repo.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	if c.Sort != sort || c.Desc != desc {
+		return nil, fmt.Errorf("%w: cursor was issued for another sort order", ErrInvalidCursor)
+	}
+
+	rc := repo.Cursor{Value: nil, ID: c.ID}
+
+	switch sort {
+	case repo.SortByCreatedAt, repo.SortByUpdatedAt:
+		var t time.Time
+		err = json.Unmarshal(c.Value, &t)
+		rc.Value = t
+	case repo.SortByFeatureID:
+		var id int
+		err = json.Unmarshal(c.Value, &id)
+		rc.Value = id
+	case repo.SortByID:
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	return &rc, nil
+}
@@ -2,4 +2,10 @@ package bannerservice
 
 import "errors"
 
-var ErrNotFound = errors.New("banner not found")
+var (
+	ErrNotFound      = errors.New("banner not found")
+	ErrUnavailable   = errors.New("banner storage unavailable")
+	ErrInvalidFilter = errors.New("invalid filter")
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidImport = errors.New("import rejected")
+)
@@ -1,10 +1,108 @@
 package bannerservice
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+)
+
 type GetBannerRequest struct {
+	IDs             []int64
 	FeatureID       int
+	FeatureIDs      []int
 	Tags            []int
+	TagMatch        repo.TagMatch
+	Active          *bool
+	CreatedFrom     time.Time
+	CreatedTo       time.Time
+	UpdatedFrom     time.Time
+	UpdatedTo       time.Time
+	Search          string
 	Offset          int
 	Limit           int
 	IsAdmin         bool
 	UseLastRevision bool
+	ContentEq       map[string]interface{}
+	ContentPath     string
+	Sort            repo.SortField
+	Desc            bool
+	// Cursor - непрозрачный курсор из GetBannerResponse.NextCursor.
+	Cursor string
+	// WithTotal запрашивает общее число подходящих баннеров.
+	WithTotal bool
+}
+
+func (req GetBannerRequest) repoRequest() repo.GetBannerRequest {
+	return repo.GetBannerRequest{
+		IDs:         req.IDs,
+		FeatureID:   req.FeatureID,
+		FeatureIDs:  req.FeatureIDs,
+		Tags:        req.Tags,
+		TagMatch:    req.TagMatch,
+		Offset:      req.Offset,
+		Limit:       req.Limit,
+		OnlyActive:  !req.IsAdmin,
+		Active:      req.Active,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		UpdatedFrom: req.UpdatedFrom,
+		UpdatedTo:   req.UpdatedTo,
+		Search:      req.Search,
+		Sort:        req.Sort,
+		Desc:        req.Desc,
+		After:       nil,
+		ContentEq:   req.ContentEq,
+		ContentPath: req.ContentPath,
+	}
+}
+
+// ParseContentFilter разбирает фильтры содержимого вида key=value. Значение,
+// которое не разбирается как JSON, считается строкой.
+func ParseContentFilter(filters []string) (map[string]interface{}, error) {
+	eq := make(map[string]interface{}, len(filters))
+
+	for _, f := range filters {
+		key, value, ok := strings.Cut(f, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%w: expected key=value, got %q", ErrInvalidFilter, f)
+		}
+
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			v = value
+		}
+
+		eq[key] = v
+	}
+
+	return eq, nil
+}
+
+// GetUserBannersRequest запрашивает баннеры одного пользователя для нескольких пар фича-тэг.
+type GetUserBannersRequest struct {
+	Pairs           []repo.FeatureTag
+	IsAdmin         bool
+	UseLastRevision bool
+}
+
+type ImportRequest struct {
+	Lines []ImportLine
+	// Upsert обновляет баннеры с совпадающим external_key.
+	Upsert bool
+	// DryRun проверяет и выполняет импорт без сохранения результата.
+	DryRun bool
+	// BestEffort пропускает ошибочные строки, иначе любая ошибка
+	// отменяет импорт целиком.
+	BestEffort bool
+}
+
+// ImportLine - строка NDJSON. Err содержит ошибку разбора строки.
+type ImportLine struct {
+	Line   int
+	Banner models.Banner
+	Err    error
 }
@@ -0,0 +1,50 @@
+package bannerservice
+
+import (
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+)
+
+type GetBannerResponse struct {
+	Banners []models.Banner
+	// Stale выставляется, когда БД недоступна и баннер отдан из кэша
+	// без проверки актуальности.
+	Stale bool
+	// Age - время, прошедшее с момента записи баннера в кэш.
+	// Для баннеров, полученных из БД, равно нулю.
+	Age time.Duration
+	// MaxAge - сколько еще ответ останется свежим, для заголовка Cache-Control.
+	MaxAge time.Duration
+	// NextCursor указывает на следующую страницу, пустой на последней.
+	NextCursor string
+	// Total заполняется, если в запросе выставлен WithTotal.
+	Total int
+}
+
+type GetUserBannersResponse struct {
+	// Banners содержит подходящие баннеры каждой найденной пары.
+	// Пар, для которых баннер не найден, в отображении нет.
+	Banners map[repo.FeatureTag][]models.Banner
+	// Stale выставляется, когда БД недоступна и часть баннеров отдана из кэша.
+	Stale bool
+	// Failed содержит пары, баннеры которых не получены из-за недоступности БД.
+	Failed map[repo.FeatureTag]struct{}
+}
+
+// ImportResponse - отчет об импорте. При DryRun счетчики показывают, что
+// произошло бы при настоящем импорте.
+type ImportResponse struct {
+	Total   int
+	Created int
+	Updated int
+	Failed  int
+	DryRun  bool
+	Errors  []ImportError
+}
+
+type ImportError struct {
+	Line  int
+	Error string
+}
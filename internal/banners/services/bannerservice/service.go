@@ -4,131 +4,509 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/bannercache"
 	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+	"github.com/Leopold1975/banners_control/internal/banners/services/changebus"
+	"github.com/Leopold1975/banners_control/internal/pkg/breaker"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
 	"github.com/Leopold1975/banners_control/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const tracerName = "github.com/Leopold1975/banners_control/internal/banners/services/bannerservice"
+
 type BannerService struct {
 	bannerRepo  Repository
 	bannerCache Cache
-	lg          logger.Logger
+	cfg         config.RedisCache
+	metrics     Metrics
+	changes     Changes
+	// revalidating содержит пары фича-тэг, для которых уже идет фоновое обновление.
+	revalidating *sync.Map
 }
 
 type Repository interface {
 	CreateBanner(context.Context, models.Banner) (int, error)
-	UpdateBanner(context.Context, models.Banner) error
-	DeleteBanner(context.Context, int) error
+	UpdateBanner(context.Context, models.Banner) (models.Banner, error)
+	DeleteBanner(context.Context, int) (models.Banner, error)
 	GetBannerByFeatureAndTags(context.Context, repo.GetBannerRequest) ([]models.Banner, error)
+	GetBannersByPairs(context.Context, repo.GetBannersByPairsRequest) ([]models.Banner, error)
+	CountBanners(context.Context, repo.GetBannerRequest) (int, error)
+	ExportBanners(context.Context, repo.GetBannerRequest, func(models.Banner) error) error
+	ImportBanners(context.Context, repo.ImportRequest) (repo.ImportResult, error)
 	Shutdown(context.Context) error
 }
 
 type Cache interface {
-	GetUserBanner(ctx context.Context, featureID int, tagID int) (models.Banner, error)
+	GetUserBanner(ctx context.Context, featureID int, tagID int) (bannercache.CachedBanner, error)
+	GetUserBanners(context.Context, []repo.FeatureTag) (map[repo.FeatureTag]bannercache.CachedBanner, error)
 	CreateBanner(context.Context, models.Banner) error
+	CreateBanners(context.Context, []models.Banner) error
 	DeleteBanner(context.Context, int) error
+	MarkWarm(context.Context) error
+}
+
+type Metrics interface {
+	CacheHit()
+	CacheMiss()
+	ObserveRefresh(duration time.Duration, err error)
+}
+
+// Changes получает изменения баннеров для рассылки подписчикам.
+type Changes interface {
+	Publish(context.Context, changebus.Event) (changebus.Event, error)
+}
+
+// Elector сообщает, является ли текущая реплика лидером. Фоновое
+// обновление кэша выполняется только на лидере.
+type Elector interface {
+	IsLeader() bool
+	Acquired() <-chan struct{}
 }
 
-func New(bannerRepo Repository, bannerCache Cache, lg logger.Logger) *BannerService {
+func New(bannerRepo Repository, bannerCache Cache, cfg config.RedisCache, m Metrics,
+	changes Changes,
+) *BannerService {
 	return &BannerService{
-		bannerRepo:  bannerRepo,
-		bannerCache: bannerCache,
-		lg:          lg,
+		bannerRepo:   bannerRepo,
+		bannerCache:  bannerCache,
+		cfg:          cfg,
+		metrics:      m,
+		changes:      changes,
+		revalidating: new(sync.Map),
 	}
 }
 
-func (bs *BannerService) GetBanner(ctx context.Context, req GetBannerRequest) ([]models.Banner, error) {
-	repoReq := repo.GetBannerRequest{
-		FeatureID:  req.FeatureID,
-		Tags:       req.Tags,
-		Offset:     req.Offset,
-		Limit:      req.Limit,
-		OnlyActive: !req.IsAdmin,
+func (bs *BannerService) GetBanner(ctx context.Context, req GetBannerRequest) (GetBannerResponse, error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "BannerService.GetBanner", trace.WithAttributes(
+		attribute.Int("banner.feature_id", req.FeatureID),
+		attribute.IntSlice("banner.tag_ids", req.Tags),
+		attribute.Bool("banner.use_last_revision", req.UseLastRevision),
+		attribute.Bool("user.is_admin", req.IsAdmin),
+	))
+	defer span.End()
+
+	if req.Sort == "" {
+		req.Sort = repo.SortByID
 	}
 
+	repoReq := req.repoRequest()
+
+	// stale - баннер из кэша старше ExpTime, который отдается, только если БД недоступна.
+	var stale *bannercache.CachedBanner
+
 	if !req.UseLastRevision && !req.IsAdmin {
 		b, err := bs.bannerCache.GetUserBanner(ctx, repoReq.FeatureID, repoReq.Tags[0])
-		if err != nil {
-			bs.lg.Info("cache missed")
-			bs.lg.Error("delete banner cache error: %s", err.Error())
-		} else {
-			bs.lg.Info("cache hit")
 
-			return []models.Banner{b}, nil
+		switch {
+		case err != nil:
+			bs.metrics.CacheMiss()
+			logger.FromContext(ctx).Infof("cache missed: %s", err.Error())
+		case bs.expired(b):
+			bs.metrics.CacheMiss()
+			logger.FromContext(ctx).Info("cache expired")
+
+			stale = &b
+		default:
+			bs.metrics.CacheHit()
+			logger.FromContext(ctx).Info("cache hit")
+			span.SetAttributes(attribute.Bool("cache.hit", true))
+
+			age := time.Since(b.CachedAt)
+			if bs.cfg.SoftExpTime != 0 && age > bs.cfg.SoftExpTime {
+				bs.revalidate(ctx, repoReq.FeatureID, repoReq.Tags[0])
+			}
+
+			return GetBannerResponse{
+				Banners: []models.Banner{b.Banner},
+				Stale:   false,
+				Age:     age,
+				MaxAge:  bs.maxAge(age),
+			}, nil
 		}
 	}
 
+	if req.Cursor != "" {
+		after, err := decodeCursor(req.Cursor, req.Sort, req.Desc)
+		if err != nil {
+			return GetBannerResponse{}, err
+		}
+
+		repoReq.After = after
+	}
+
+	// Запрашиваем на одну строку больше, чтобы узнать, есть ли следующая страница.
+	if req.Limit != 0 {
+		repoReq.Limit = req.Limit + 1
+	}
+
 	banners, err := bs.bannerRepo.GetBannerByFeatureAndTags(ctx, repoReq)
 	if err != nil {
-		return nil, fmt.Errorf("get banner error: %w", err)
+		// Пользователю при недоступной БД лучше получить последнее
+		// известное значение из кэша, чем ошибку.
+		if !req.IsAdmin && isStorageFailure(err) {
+			if stale == nil && req.UseLastRevision {
+				if b, errC := bs.bannerCache.GetUserBanner(ctx, repoReq.FeatureID, repoReq.Tags[0]); errC == nil {
+					stale = &b
+				}
+			}
+
+			if stale != nil {
+				logger.FromContext(ctx).Warnf("serving stale banner from cache, get banner error: %s", err.Error())
+
+				return GetBannerResponse{
+					Banners: []models.Banner{stale.Banner},
+					Stale:   true,
+					Age:     time.Since(stale.CachedAt),
+					MaxAge:  0,
+				}, nil
+			}
+		}
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "get banner error")
+
+		return GetBannerResponse{}, listError("get banner error", err)
+	}
+
+	resp := GetBannerResponse{Banners: banners, Stale: false, Age: 0, MaxAge: bs.maxAge(0), NextCursor: "", Total: 0}
+
+	if req.Limit != 0 && len(banners) > req.Limit {
+		resp.Banners = banners[:req.Limit]
+
+		resp.NextCursor, err = encodeCursor(req.Sort, req.Desc, resp.Banners[req.Limit-1])
+		if err != nil {
+			return GetBannerResponse{}, err
+		}
+	}
+
+	if req.WithTotal {
+		resp.Total, err = bs.bannerRepo.CountBanners(ctx, repoReq)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "count banners error")
+
+			return GetBannerResponse{}, listError("count banners error", err)
+		}
+	}
+
+	return resp, nil
+}
+
+func listError(msg string, err error) error {
+	if errors.Is(err, breaker.ErrUnavailable) {
+		return ErrUnavailable
+	}
+
+	if errors.Is(err, repo.ErrInvalidFilter) {
+		return fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+	}
+
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// GetUserBanners отдает баннеры для нескольких пар фича-тэг: сначала из кэша
+// одним обращением, затем недостающие из БД одним запросом. Пары без баннера
+// не считаются ошибкой, ошибка возвращается, только если не найдено ничего.
+// Если БД недоступна, а часть баннеров найдена в кэше, остальные пары
+// перечисляются в Failed.
+func (bs *BannerService) GetUserBanners(ctx context.Context, //nolint:cyclop
+	req GetUserBannersRequest,
+) (GetUserBannersResponse, error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "BannerService.GetUserBanners", trace.WithAttributes(
+		attribute.Int("banner.pairs", len(req.Pairs)),
+		attribute.Bool("banner.use_last_revision", req.UseLastRevision),
+		attribute.Bool("user.is_admin", req.IsAdmin),
+	))
+	defer span.End()
+
+	resp := GetUserBannersResponse{
+		Banners: make(map[repo.FeatureTag][]models.Banner, len(req.Pairs)),
+		Stale:   false,
+		Failed:  nil,
+	}
+	missing := req.Pairs
+	// stale - баннеры из кэша старше ExpTime, которые отдаются, только если БД недоступна.
+	stale := make(map[repo.FeatureTag]bannercache.CachedBanner)
+
+	if !req.UseLastRevision && !req.IsAdmin {
+		cached, err := bs.bannerCache.GetUserBanners(ctx, req.Pairs)
+		if err != nil {
+			logger.FromContext(ctx).Infof("cache missed: %s", err.Error())
+		}
+
+		missing = make([]repo.FeatureTag, 0, len(req.Pairs))
+
+		for _, p := range req.Pairs {
+			b, ok := cached[p]
+			if !ok || bs.expired(b) {
+				bs.metrics.CacheMiss()
+
+				if ok {
+					stale[p] = b
+				}
+
+				missing = append(missing, p)
+
+				continue
+			}
+
+			bs.metrics.CacheHit()
+
+			resp.Banners[p] = []models.Banner{b.Banner}
+
+			if age := time.Since(b.CachedAt); bs.cfg.SoftExpTime != 0 && age > bs.cfg.SoftExpTime {
+				bs.revalidate(ctx, p.FeatureID, p.TagID)
+			}
+		}
+	}
+
+	if len(missing) == 0 {
+		return resp, nil
+	}
+
+	banners, err := bs.bannerRepo.GetBannersByPairs(ctx, repo.GetBannersByPairsRequest{
+		Pairs:      missing,
+		OnlyActive: !req.IsAdmin,
+	})
+	if err != nil {
+		if !req.IsAdmin && isStorageFailure(err) {
+			if req.UseLastRevision {
+				if cached, errC := bs.bannerCache.GetUserBanners(ctx, missing); errC == nil {
+					stale = cached
+				}
+			}
+
+			for p, b := range stale {
+				resp.Banners[p] = []models.Banner{b.Banner}
+				resp.Stale = true
+			}
+		}
+
+		if len(resp.Banners) != 0 {
+			logger.FromContext(ctx).Warnf("get banners error, returning cached part: %s", err.Error())
+
+			// Пары, для которых баннер не удалось получить ни из БД, ни из кэша,
+			// отмечаются отдельно, чтобы не выдавать их за ненайденные.
+			resp.Failed = make(map[repo.FeatureTag]struct{})
+
+			for _, p := range missing {
+				if _, ok := resp.Banners[p]; !ok {
+					resp.Failed[p] = struct{}{}
+				}
+			}
+
+			return resp, nil
+		}
+
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "get banners error")
+
+		if errors.Is(err, breaker.ErrUnavailable) {
+			return GetUserBannersResponse{}, ErrUnavailable
+		}
+
+		return GetUserBannersResponse{}, fmt.Errorf("get banners error: %w", err)
+	}
+
+	for _, b := range banners {
+		for _, p := range missing {
+			if b.FeatureID == p.FeatureID && slices.Contains(b.Tags, p.TagID) {
+				resp.Banners[p] = append(resp.Banners[p], b)
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// maxAge возвращает, сколько еще ответ возраста age останется свежим в кэше:
+// до мягкого TTL, если он задан, иначе до жесткого.
+func (bs *BannerService) maxAge(age time.Duration) time.Duration {
+	ttl := bs.cfg.ExpTime
+	if bs.cfg.SoftExpTime != 0 {
+		ttl = bs.cfg.SoftExpTime
+	}
+
+	return max(ttl-age, 0)
+}
+
+// revalidate обновляет в кэше баннеры фичи и тэга в фоне. Пока обновление
+// не завершено, пользователи получают устаревшее значение из кэша.
+func (bs *BannerService) revalidate(ctx context.Context, featureID, tagID int) {
+	key := fmt.Sprintf("%d:%d", featureID, tagID)
+	if _, loaded := bs.revalidating.LoadOrStore(key, struct{}{}); loaded {
+		return
 	}
 
-	return banners, nil
+	go func() {
+		defer bs.revalidating.Delete(key)
+
+		ctx, span := otel.Tracer(tracerName).Start(context.WithoutCancel(ctx), "BannerService.revalidate",
+			trace.WithAttributes(attribute.Int("banner.feature_id", featureID), attribute.Int("banner.tag_id", tagID)))
+		defer span.End()
+
+		ctx, cancel := context.WithTimeout(ctx, bs.cfg.SoftExpTime)
+		defer cancel()
+
+		banners, err := bs.bannerRepo.GetBannerByFeatureAndTags(ctx, repo.GetBannerRequest{ //nolint:exhaustruct
+			FeatureID: featureID,
+			Tags:      []int{tagID},
+		})
+		if err != nil {
+			logger.FromContext(ctx).Errorf("revalidate banner error: %s", err.Error())
+
+			return
+		}
+
+		if err := bs.bannerCache.CreateBanners(ctx, banners); err != nil {
+			logger.FromContext(ctx).Errorf("revalidate banner cache error: %s", err.Error())
+		}
+	}()
+}
+
+// expired сообщает, что баннер из кэша старше ExpTime и должен быть перечитан из БД.
+func (bs *BannerService) expired(b bannercache.CachedBanner) bool {
+	return bs.cfg.ExpTime != 0 && time.Since(b.CachedAt) > bs.cfg.ExpTime
+}
+
+// isStorageFailure отличает отказ БД (разомкнутый выключатель, ошибку соединения
+// или запроса) от ошибок самого запроса - так же, как выключатель хранилища.
+func isStorageFailure(err error) bool {
+	return !errors.Is(err, repo.ErrNotFound) && !errors.Is(err, context.Canceled)
 }
 
 func (bs *BannerService) CreateBanner(ctx context.Context, b models.Banner) (int, error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "BannerService.CreateBanner")
+	defer span.End()
+
 	b.CreatedAt = time.Now()
 	b.UpdatedAt = time.Now()
 
 	id, err := bs.bannerRepo.CreateBanner(ctx, b)
 	if err != nil {
+		if errors.Is(err, breaker.ErrUnavailable) {
+			return 0, ErrUnavailable
+		}
+
 		return 0, fmt.Errorf("create banner error: %w", err)
 	}
 
 	b.ID = int64(id)
 
 	if err := bs.bannerCache.CreateBanner(ctx, b); err != nil {
-		bs.lg.Error("create banner cache error: %s", err.Error)
+		logger.FromContext(ctx).Errorf("create banner cache error: %s", err.Error())
 	}
 
+	bs.publish(ctx, changebus.Event{Type: changebus.Created, Banner: b}) //nolint:exhaustruct
+
 	return id, nil
 }
 
 func (bs BannerService) DeleteBanner(ctx context.Context, id int) error {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "BannerService.DeleteBanner")
+	defer span.End()
+
 	if err := bs.bannerCache.DeleteBanner(ctx, id); err != nil {
-		bs.lg.Error("delete banner cache errorL %s", err.Error())
+		logger.FromContext(ctx).Errorf("delete banner cache error: %s", err.Error())
 	}
 
-	if err := bs.bannerRepo.DeleteBanner(ctx, id); err != nil {
+	b, err := bs.bannerRepo.DeleteBanner(ctx, id)
+	if err != nil {
 		if errors.Is(err, repo.ErrNotFound) {
 			return ErrNotFound
 		}
+
+		if errors.Is(err, breaker.ErrUnavailable) {
+			return ErrUnavailable
+		}
+
+		return fmt.Errorf("delete banner error: %w", err)
 	}
 
+	bs.publish(ctx, changebus.Event{Type: changebus.Deleted, Banner: b}) //nolint:exhaustruct
+
 	return nil
 }
 
 func (bs *BannerService) UpdateBanner(ctx context.Context, banner models.Banner) error {
-	if err := bs.bannerRepo.UpdateBanner(ctx, banner); err != nil {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "BannerService.UpdateBanner")
+	defer span.End()
+
+	// Время изменения служит версией баннера, в том числе для ETag.
+	banner.UpdatedAt = time.Now()
+
+	prev, err := bs.bannerRepo.UpdateBanner(ctx, banner)
+	if err != nil {
 		if errors.Is(err, repo.ErrNotFound) {
 			return ErrNotFound
 		}
 
+		if errors.Is(err, breaker.ErrUnavailable) {
+			return ErrUnavailable
+		}
+
 		return fmt.Errorf("update banner error: %w", err)
 	}
 
+	banner.CreatedAt, banner.ExternalKey = prev.CreatedAt, prev.ExternalKey
+
+	bs.publish(ctx, changebus.Event{Type: changebus.Updated, Banner: banner, Previous: &prev}) //nolint:exhaustruct
+
 	return nil
 }
 
-func (bs *BannerService) BackroundRefresh(ctx context.Context, ttl time.Duration) {
+// publish рассылает изменение подписчикам. Баннер уже сохранен, поэтому
+// ошибка публикации только записывается в лог.
+func (bs *BannerService) publish(ctx context.Context, e changebus.Event) {
+	if _, err := bs.changes.Publish(ctx, e); err != nil {
+		logger.FromContext(ctx).Errorf("publish banner change error: %s", err.Error())
+	}
+}
+
+// BackroundRefresh обновляет кэш раз в ttl, пока реплика лидер. Новый лидер
+// обновляет кэш сразу, не дожидаясь очередного срабатывания таймера.
+func (bs *BannerService) BackroundRefresh(ctx context.Context, ttl time.Duration, elector Elector) {
 	t := time.NewTicker(ttl)
+	defer t.Stop()
 
-	err := bs.refresh(ctx)
-	if err != nil {
-		bs.lg.Error("refresh error: %s", err.Error())
+	// Лидерство, полученное до запуска, покрывается первым обновлением.
+	select {
+	case <-elector.Acquired():
+	default:
+	}
+
+	if elector.IsLeader() {
+		err := bs.refresh(ctx)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("refresh error: %s", err.Error())
+		}
 	}
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-elector.Acquired():
+			t.Reset(ttl)
 		case <-t.C:
-			err = bs.refresh(ctx)
-			if err != nil {
-				bs.lg.Error("refresh error: %s", err.Error())
-			}
+		}
+
+		if !elector.IsLeader() {
+			continue
+		}
+
+		err := bs.refresh(ctx)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("refresh error: %s", err.Error())
 		}
 	}
 }
@@ -141,7 +519,14 @@ func (bs *BannerService) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-func (bs *BannerService) refresh(ctx context.Context) error {
+func (bs *BannerService) refresh(ctx context.Context) (err error) { //nolint:nonamedreturns
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "BannerService.refresh")
+	defer span.End()
+
+	defer func(start time.Time) {
+		bs.metrics.ObserveRefresh(time.Since(start), err)
+	}(time.Now())
+
 	errCh := make(chan error)
 	go func() {
 		defer close(errCh)
@@ -156,12 +541,16 @@ func (bs *BannerService) refresh(ctx context.Context) error {
 			return
 		}
 
-		for _, b := range banners {
-			if err := bs.bannerCache.CreateBanner(ctx, b); err != nil {
-				errCh <- fmt.Errorf("create banner cache error: %w", err)
+		if err := bs.bannerCache.CreateBanners(ctx, banners); err != nil {
+			errCh <- fmt.Errorf("create banners cache error: %w", err)
 
-				return
-			}
+			return
+		}
+
+		if err := bs.bannerCache.MarkWarm(ctx); err != nil {
+			errCh <- fmt.Errorf("mark cache warm error: %w", err)
+
+			return
 		}
 	}()
 
@@ -170,7 +559,7 @@ func (bs *BannerService) refresh(ctx context.Context) error {
 		return fmt.Errorf("context cancelled error: %w", ctx.Err())
 	case err := <-errCh:
 		if err != nil {
-			bs.lg.Error("refresh error: %s", err.Error())
+			logger.FromContext(ctx).Errorf("refresh error: %s", err.Error())
 
 			return err
 		}
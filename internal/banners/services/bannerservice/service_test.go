@@ -0,0 +1,381 @@
+package bannerservice_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/bannercache"
+	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+	"github.com/Leopold1975/banners_control/internal/banners/services/bannerservice"
+	"github.com/Leopold1975/banners_control/internal/banners/services/changebus"
+	"github.com/Leopold1975/banners_control/internal/pkg/breaker"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/internal/pkg/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+// repoMock хранит баннеры в памяти и отбирает их по фиче, тэгам и активности.
+// Если задан err, все чтения завершаются этой ошибкой, если задан block,
+// чтения ждут его закрытия.
+type repoMock struct {
+	bannerservice.Repository
+
+	banners []models.Banner
+	err     error
+	block   chan struct{}
+	reads   atomic.Int32
+}
+
+func (r *repoMock) GetBannerByFeatureAndTags(_ context.Context, req repo.GetBannerRequest) ([]models.Banner, error) {
+	r.reads.Add(1)
+
+	if r.block != nil {
+		<-r.block
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var banners []models.Banner
+
+	for _, b := range r.banners {
+		if (req.FeatureID != 0 && b.FeatureID != req.FeatureID) || (req.OnlyActive && !b.Active) ||
+			(len(req.Tags) != 0 && !slices.ContainsFunc(req.Tags, func(t int) bool { return slices.Contains(b.Tags, t) })) ||
+			(req.After != nil && b.ID <= req.After.ID) {
+			continue
+		}
+
+		banners = append(banners, b)
+	}
+
+	if req.Limit != 0 && len(banners) > req.Limit {
+		banners = banners[:req.Limit]
+	}
+
+	return banners, nil
+}
+
+func (r *repoMock) GetBannersByPairs(_ context.Context, req repo.GetBannersByPairsRequest) ([]models.Banner, error) {
+	r.reads.Add(1)
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var banners []models.Banner
+
+	for _, b := range r.banners {
+		if req.OnlyActive && !b.Active {
+			continue
+		}
+
+		if slices.ContainsFunc(req.Pairs, func(p repo.FeatureTag) bool {
+			return p.FeatureID == b.FeatureID && slices.Contains(b.Tags, p.TagID)
+		}) {
+			banners = append(banners, b)
+		}
+	}
+
+	return banners, nil
+}
+
+func (r *repoMock) CountBanners(ctx context.Context, req repo.GetBannerRequest) (int, error) {
+	req.Limit, req.After = 0, nil
+
+	banners, err := r.GetBannerByFeatureAndTags(ctx, req)
+
+	return len(banners), err
+}
+
+// cacheMock хранит баннер пользователя для пары фича-тэг. О записи в кэш
+// сообщает created, если он задан.
+type cacheMock struct {
+	bannerservice.Cache
+
+	mu      sync.Mutex
+	banners map[repo.FeatureTag]bannercache.CachedBanner
+	created chan struct{}
+}
+
+func (c *cacheMock) GetUserBanner(_ context.Context, featureID, tagID int) (bannercache.CachedBanner, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.banners[repo.FeatureTag{FeatureID: featureID, TagID: tagID}]
+	if !ok {
+		return bannercache.CachedBanner{}, repo.ErrNotFound
+	}
+
+	return b, nil
+}
+
+func (c *cacheMock) GetUserBanners(_ context.Context,
+	pairs []repo.FeatureTag,
+) (map[repo.FeatureTag]bannercache.CachedBanner, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := make(map[repo.FeatureTag]bannercache.CachedBanner)
+
+	for _, p := range pairs {
+		if b, ok := c.banners[p]; ok {
+			res[p] = b
+		}
+	}
+
+	return res, nil
+}
+
+func (c *cacheMock) CreateBanners(_ context.Context, banners []models.Banner) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, b := range banners {
+		for _, tagID := range b.Tags {
+			c.banners[repo.FeatureTag{FeatureID: b.FeatureID, TagID: tagID}] = bannercache.CachedBanner{Banner: b, CachedAt: time.Now()}
+		}
+	}
+
+	if c.created != nil {
+		c.created <- struct{}{}
+	}
+
+	return nil
+}
+
+type changesMock struct{}
+
+func (changesMock) Publish(_ context.Context, e changebus.Event) (changebus.Event, error) {
+	return e, nil
+}
+
+func newService(t *testing.T, r *repoMock, c *cacheMock) *bannerservice.BannerService {
+	t.Helper()
+
+	return bannerservice.New(r, c, config.RedisCache{ExpTime: time.Minute, SoftExpTime: 10 * time.Second}, metrics.New(), changesMock{}) //nolint:exhaustruct
+}
+
+func TestStaleOnStorageFailure(t *testing.T) {
+	ctx := context.Background()
+
+	fresh := models.Banner{ID: 1, FeatureID: 1, Tags: []int{2}, Active: true, Content: map[string]interface{}{"v": "new"}} //nolint:exhaustruct
+	cached := fresh
+	cached.Content = map[string]interface{}{"v": "old"}
+
+	r := &repoMock{banners: []models.Banner{fresh}}                        //nolint:exhaustruct
+	c := &cacheMock{banners: map[repo.FeatureTag]bannercache.CachedBanner{ //nolint:exhaustruct
+		{FeatureID: 1, TagID: 2}: {Banner: cached, CachedAt: time.Now().Add(-2 * time.Minute)},
+	}}
+	bs := newService(t, r, c)
+
+	req := bannerservice.GetBannerRequest{FeatureID: 1, Tags: []int{2}} //nolint:exhaustruct
+
+	// Запись старше ExpTime не отдается, пока БД доступна.
+	resp, err := bs.GetBanner(ctx, req)
+	require.NoError(t, err)
+	require.False(t, resp.Stale)
+	require.Equal(t, "new", resp.Banners[0].Content["v"])
+
+	// При отказе БД пользователь получает последнюю известную версию.
+	r.err = breaker.ErrUnavailable
+
+	for _, useLast := range []bool{false, true} {
+		req.UseLastRevision = useLast
+
+		resp, err = bs.GetBanner(ctx, req)
+		require.NoError(t, err)
+		require.True(t, resp.Stale)
+		require.Equal(t, "old", resp.Banners[0].Content["v"])
+	}
+
+	// Админ всегда получает ошибку хранилища.
+	req.IsAdmin = true
+
+	_, err = bs.GetBanner(ctx, req)
+	require.ErrorIs(t, err, bannerservice.ErrUnavailable)
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	ctx := context.Background()
+
+	fresh := models.Banner{ID: 1, FeatureID: 1, Tags: []int{2}, Active: true, Content: map[string]interface{}{"v": "new"}} //nolint:exhaustruct
+	cached := fresh
+	cached.Content = map[string]interface{}{"v": "old"}
+	cachedAt := time.Now().Add(-30 * time.Second)
+
+	r := &repoMock{banners: []models.Banner{fresh}, block: make(chan struct{})} //nolint:exhaustruct
+	c := &cacheMock{banners: map[repo.FeatureTag]bannercache.CachedBanner{      //nolint:exhaustruct
+		{FeatureID: 1, TagID: 2}: {Banner: cached, CachedAt: cachedAt},
+	}, created: make(chan struct{}, 1)}
+	bs := newService(t, r, c)
+
+	req := bannerservice.GetBannerRequest{FeatureID: 1, Tags: []int{2}} //nolint:exhaustruct
+
+	// Между мягким и жестким TTL отдается значение из кэша, а обновление
+	// запускается в фоне один раз, сколько бы запросов ни пришло.
+	for range 3 {
+		resp, err := bs.GetBanner(ctx, req)
+		require.NoError(t, err)
+		require.False(t, resp.Stale)
+		require.Equal(t, "old", resp.Banners[0].Content["v"])
+		require.InDelta(t, time.Since(cachedAt), resp.Age, float64(time.Second))
+	}
+
+	close(r.block)
+
+	select {
+	case <-c.created:
+	case <-time.After(time.Second):
+		t.Fatal("banner not revalidated")
+	}
+
+	require.Equal(t, int32(1), r.reads.Load())
+
+	resp, err := bs.GetBanner(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "new", resp.Banners[0].Content["v"])
+	require.Less(t, resp.Age, time.Second)
+	require.Equal(t, int32(1), r.reads.Load())
+
+	// После жесткого TTL баннер читается из БД.
+	c.mu.Lock()
+	c.banners[repo.FeatureTag{FeatureID: 1, TagID: 2}] = bannercache.CachedBanner{Banner: cached, CachedAt: time.Now().Add(-2 * time.Minute)}
+	c.mu.Unlock()
+
+	resp, err = bs.GetBanner(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "new", resp.Banners[0].Content["v"])
+	require.Zero(t, resp.Age)
+	require.Equal(t, int32(2), r.reads.Load())
+}
+
+func TestGetUserBanners(t *testing.T) {
+	ctx := context.Background()
+
+	banner := func(id int64, featureID int, active bool, tags ...int) models.Banner {
+		return models.Banner{ID: id, FeatureID: featureID, Tags: tags, Active: active} //nolint:exhaustruct
+	}
+
+	r := &repoMock{banners: []models.Banner{ //nolint:exhaustruct
+		banner(1, 1, true, 1, 2),
+		banner(2, 1, true, 3),
+		banner(3, 2, false, 1),
+		banner(4, 2, true, 2),
+		banner(5, 2, true, 2, 3),
+	}}
+	c := &cacheMock{banners: map[repo.FeatureTag]bannercache.CachedBanner{ //nolint:exhaustruct
+		{FeatureID: 1, TagID: 1}: {Banner: banner(1, 1, true, 1, 2), CachedAt: time.Now()},
+	}}
+	bs := newService(t, r, c)
+
+	pairs := []repo.FeatureTag{
+		{FeatureID: 1, TagID: 1},
+		{FeatureID: 1, TagID: 3},
+		{FeatureID: 2, TagID: 1},
+		{FeatureID: 2, TagID: 2},
+		{FeatureID: 3, TagID: 1},
+	}
+
+	ids := func(banners []models.Banner) []int64 {
+		res := make([]int64, 0, len(banners))
+		for _, b := range banners {
+			res = append(res, b.ID)
+		}
+
+		return res
+	}
+
+	// Пользователь не видит выключенный баннер 3; пары без баннера в ответ не попадают.
+	resp, err := bs.GetUserBanners(ctx, bannerservice.GetUserBannersRequest{Pairs: pairs}) //nolint:exhaustruct
+	require.NoError(t, err)
+	require.False(t, resp.Stale)
+	require.Empty(t, resp.Failed)
+	require.Len(t, resp.Banners, 3)
+	require.Equal(t, []int64{1}, ids(resp.Banners[pairs[0]]))
+	require.Equal(t, []int64{2}, ids(resp.Banners[pairs[1]]))
+	require.Equal(t, []int64{4, 5}, ids(resp.Banners[pairs[3]]))
+
+	// Админ читает из БД и видит выключенные баннеры.
+	resp, err = bs.GetUserBanners(ctx, bannerservice.GetUserBannersRequest{Pairs: pairs, IsAdmin: true}) //nolint:exhaustruct
+	require.NoError(t, err)
+	require.Len(t, resp.Banners, 4)
+	require.Equal(t, []int64{3}, ids(resp.Banners[pairs[2]]))
+
+	// При отказе БД отдается найденное в кэше, включая устаревшие записи,
+	// а остальные пары отмечаются как неполученные, а не ненайденные.
+	c.banners[pairs[3]] = bannercache.CachedBanner{Banner: banner(4, 2, true, 2), CachedAt: time.Now().Add(-2 * time.Minute)}
+	r.err = breaker.ErrUnavailable
+
+	resp, err = bs.GetUserBanners(ctx, bannerservice.GetUserBannersRequest{Pairs: pairs}) //nolint:exhaustruct
+	require.NoError(t, err)
+	require.True(t, resp.Stale)
+	require.Len(t, resp.Banners, 2)
+	require.Equal(t, []int64{1}, ids(resp.Banners[pairs[0]]))
+	require.Equal(t, []int64{4}, ids(resp.Banners[pairs[3]]))
+	require.Equal(t, map[repo.FeatureTag]struct{}{pairs[1]: {}, pairs[2]: {}, pairs[4]: {}}, resp.Failed)
+
+	// Если не найдено ничего, возвращается ошибка хранилища.
+	_, err = bs.GetUserBanners(ctx, bannerservice.GetUserBannersRequest{Pairs: pairs[1:3]}) //nolint:exhaustruct
+	require.ErrorIs(t, err, bannerservice.ErrUnavailable)
+}
+
+func TestGetBannerPagination(t *testing.T) {
+	ctx := context.Background()
+
+	r := &repoMock{} //nolint:exhaustruct
+	for id := int64(1); id <= 5; id++ {
+		r.banners = append(r.banners, models.Banner{ID: id, FeatureID: 1, Tags: []int{1}, Active: id != 3}) //nolint:exhaustruct
+	}
+
+	bs := newService(t, r, &cacheMock{}) //nolint:exhaustruct
+
+	for _, tt := range []struct {
+		isAdmin bool
+		total   int
+		pages   [][]int64
+	}{
+		{isAdmin: true, total: 5, pages: [][]int64{{1, 2}, {3, 4}, {5}}},
+		{isAdmin: false, total: 4, pages: [][]int64{{1, 2}, {4, 5}}},
+	} {
+		req := bannerservice.GetBannerRequest{ //nolint:exhaustruct
+			FeatureID:       1,
+			Tags:            []int{1},
+			Limit:           2,
+			IsAdmin:         tt.isAdmin,
+			UseLastRevision: true,
+			WithTotal:       true,
+		}
+
+		var pages [][]int64
+
+		for {
+			resp, err := bs.GetBanner(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, tt.total, resp.Total)
+
+			page := make([]int64, 0, len(resp.Banners))
+			for _, b := range resp.Banners {
+				page = append(page, b.ID)
+			}
+
+			pages = append(pages, page)
+
+			if resp.NextCursor == "" {
+				break
+			}
+
+			req.Cursor = resp.NextCursor
+		}
+
+		require.Equal(t, tt.pages, pages, "admin %v", tt.isAdmin)
+	}
+
+	_, err := bs.GetBanner(ctx, bannerservice.GetBannerRequest{FeatureID: 1, Tags: []int{1}, Cursor: "bad"}) //nolint:exhaustruct
+	require.ErrorIs(t, err, bannerservice.ErrInvalidCursor)
+}
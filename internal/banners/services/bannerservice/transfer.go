@@ -0,0 +1,159 @@
+package bannerservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	repo "github.com/Leopold1975/banners_control/internal/banners/repository/bannerrepo"
+	"github.com/Leopold1975/banners_control/internal/pkg/breaker"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExportBanners передает fn баннеры, подходящие под фильтры запроса, в порядке
+// сортировки запроса. Limit, Offset и Cursor не учитываются.
+func (bs *BannerService) ExportBanners(ctx context.Context, req GetBannerRequest, fn func(models.Banner) error) error {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "BannerService.ExportBanners")
+	defer span.End()
+
+	if req.Sort == "" {
+		req.Sort = repo.SortByID
+	}
+
+	repoReq := req.repoRequest()
+	repoReq.Offset, repoReq.Limit = 0, 0
+
+	if err := bs.bannerRepo.ExportBanners(ctx, repoReq, fn); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "export banners error")
+
+		return listError("export banners error", err)
+	}
+
+	return nil
+}
+
+// ImportBanners проверяет строки импорта и сохраняет корректные одной
+// транзакцией. Без BestEffort любая ошибка отменяет импорт и возвращается
+// ErrInvalidImport вместе с отчетом. После импорта кэш обновляется один раз.
+func (bs *BannerService) ImportBanners(ctx context.Context, //nolint:cyclop
+	req ImportRequest,
+) (ImportResponse, error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "BannerService.ImportBanners", trace.WithAttributes(
+		attribute.Int("import.lines", len(req.Lines)),
+		attribute.Bool("import.upsert", req.Upsert),
+		attribute.Bool("import.dry_run", req.DryRun),
+		attribute.Bool("import.best_effort", req.BestEffort),
+	))
+	defer span.End()
+
+	resp := ImportResponse{Total: len(req.Lines), Created: 0, Updated: 0, Failed: 0, DryRun: req.DryRun, Errors: nil}
+	fail := func(line int, err error) {
+		resp.Failed++
+		resp.Errors = append(resp.Errors, ImportError{Line: line, Error: err.Error()})
+	}
+
+	now := time.Now()
+	keys := make(map[string]int)
+	banners := make([]repo.ImportBanner, 0, len(req.Lines))
+
+	for _, l := range req.Lines {
+		if l.Err == nil {
+			l.Err = validateImport(l.Banner)
+		}
+
+		if l.Err == nil && l.Banner.ExternalKey != "" {
+			if first, ok := keys[l.Banner.ExternalKey]; ok {
+				l.Err = fmt.Errorf("duplicate external_key %q, first seen on line %d", l.Banner.ExternalKey, first)
+			} else {
+				keys[l.Banner.ExternalKey] = l.Line
+			}
+		}
+
+		if l.Err != nil {
+			fail(l.Line, l.Err)
+
+			continue
+		}
+
+		if l.Banner.CreatedAt.IsZero() {
+			l.Banner.CreatedAt = now
+		}
+
+		if l.Banner.UpdatedAt.IsZero() {
+			l.Banner.UpdatedAt = now
+		}
+
+		banners = append(banners, repo.ImportBanner{Line: l.Line, Banner: l.Banner})
+	}
+
+	if resp.Failed != 0 && !req.BestEffort {
+		return resp, ErrInvalidImport
+	}
+
+	if len(banners) == 0 {
+		return resp, nil
+	}
+
+	res, err := bs.bannerRepo.ImportBanners(ctx, repo.ImportRequest{
+		Banners:       banners,
+		Upsert:        req.Upsert,
+		SkipConflicts: req.BestEffort,
+		DryRun:        req.DryRun,
+	})
+
+	for _, line := range res.Conflicts {
+		fail(line, repo.ErrConflict)
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "import banners error")
+
+		switch {
+		case errors.Is(err, repo.ErrConflict):
+			return resp, ErrInvalidImport
+		case errors.Is(err, breaker.ErrUnavailable):
+			return ImportResponse{}, ErrUnavailable
+		default:
+			return ImportResponse{}, fmt.Errorf("import banners error: %w", err)
+		}
+	}
+
+	resp.Created, resp.Updated = res.Created, res.Updated
+
+	if !req.DryRun && res.Created+res.Updated != 0 {
+		// Импорт уже сохранен, поэтому ошибка обновления кэша его не отменяет:
+		// кэш догонит фоновое обновление.
+		if err := bs.refresh(context.WithoutCancel(ctx)); err != nil {
+			logger.FromContext(ctx).Errorf("refresh cache after import error: %s", err.Error())
+		}
+	}
+
+	return resp, nil
+}
+
+func validateImport(b models.Banner) error {
+	switch {
+	case b.FeatureID <= 0:
+		return errors.New("feature_id must be positive") //nolint:goerr113
+	case len(b.Tags) == 0:
+		return errors.New("tag_ids must not be empty") //nolint:goerr113
+	case b.Content == nil:
+		return errors.New("content is required") //nolint:goerr113
+	}
+
+	for _, t := range b.Tags {
+		if t <= 0 {
+			return fmt.Errorf("tag_id %d must be positive", t) //nolint:goerr113
+		}
+	}
+
+	return nil
+}
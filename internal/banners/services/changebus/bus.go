@@ -0,0 +1,413 @@
+package changebus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrSlowSubscriber - подписчик не успевал читать события и был отключен.
+var ErrSlowSubscriber = errors.New("subscriber is too slow")
+
+// Ключи содержат общий хэш-тэг, чтобы в режиме кластера скрипт публикации
+// работал с обоими.
+const (
+	streamKey = "{banner_changes}:stream"
+	seqKey    = "{banner_changes}:seq"
+
+	// readBlock ограничивает ожидание новых событий, чтобы чтение
+	// замечало отмену контекста.
+	readBlock     = time.Second
+	readCount     = 100
+	retryInterval = time.Second
+)
+
+// Номер события выдает счетчик, а идентификатор записи в потоке совпадает
+// с номером, поэтому номера общие для всех реплик и идут без пропусков.
+var publishScript = redis.NewScript(`
+local seq = redis.call("INCR", KEYS[2])
+redis.call("XADD", KEYS[1], "MAXLEN", "~", ARGV[2], seq .. "-0", "event", ARGV[1])
+return seq`)
+
+type EventType string
+
+const (
+	Created EventType = "created"
+	Updated EventType = "updated"
+	Deleted EventType = "deleted"
+)
+
+// Event - изменение баннера. Seq общий для всех реплик и возрастает
+// с каждым изменением.
+type Event struct {
+	Seq    uint64
+	Type   EventType
+	Banner models.Banner
+	// Previous - состояние баннера до изменения, только для Updated.
+	Previous *models.Banner
+	At       time.Time
+}
+
+// Filter отбирает события по фиче и тэгу баннера. Нулевое значение поля
+// означает любую фичу или тэг.
+type Filter struct {
+	FeatureID int
+	TagID     int
+}
+
+// Match сообщает, подходит ли под фильтр баннер до или после изменения:
+// подписчик должен узнать и о том, что баннер ушел из его фичи или тэга.
+func (f Filter) Match(e Event) bool {
+	return f.MatchBanner(e.Banner) || (e.Previous != nil && f.MatchBanner(*e.Previous))
+}
+
+func (f Filter) MatchBanner(b models.Banner) bool {
+	if f.FeatureID != 0 && b.FeatureID != f.FeatureID {
+		return false
+	}
+
+	return f.TagID == 0 || slices.Contains(b.Tags, f.TagID)
+}
+
+// Bus рассылает изменения баннеров подписчикам всех реплик. Publish
+// записывает событие в поток Redis, а Run каждой реплики читает поток
+// и раздает события своим подписчикам. Раздача не блокируется: подписчик,
+// буфер которого заполнен, отключается с ErrSlowSubscriber. Последние
+// события остаются в потоке, поэтому переподключившийся подписчик может
+// продолжить с места разрыва на любой реплике.
+type Bus struct {
+	rdb     redis.UniversalClient
+	size    int
+	history int
+	// last - идентификатор последнего прочитанного из потока события.
+	// Используется только в Run.
+	last string
+
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+	// seq - номер последнего разосланного события, started - известен ли он.
+	seq     uint64
+	started bool
+}
+
+// New создает шину. События рассылаются начиная с опубликованных после
+// создания; если Redis недоступен, - после первого успешного чтения в Run.
+func New(ctx context.Context, rdb redis.UniversalClient, cfg config.Changes) *Bus {
+	b := &Bus{
+		rdb:     rdb,
+		size:    cfg.Buffer,
+		history: cfg.History,
+		last:    "",
+		mu:      sync.Mutex{},
+		subs:    make(map[*Subscription]struct{}),
+		seq:     0,
+		started: false,
+	}
+
+	if err := b.start(ctx); err != nil {
+		logger.FromContext(ctx).Errorf("read banner changes error: %s", err.Error())
+	}
+
+	return b
+}
+
+// Publish присваивает событию номер и время и записывает его в поток.
+// Подписчикам событие доставляет Run.
+func (b *Bus) Publish(ctx context.Context, e Event) (Event, error) {
+	e.At = time.Now()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return Event{}, fmt.Errorf("marshal error: %w", err)
+	}
+
+	seq, err := publishScript.Run(ctx, b.rdb, []string{streamKey, seqKey}, data, b.history).Uint64()
+	if err != nil {
+		return Event{}, fmt.Errorf("run publish script error: %w", err)
+	}
+
+	e.Seq = seq
+
+	return e, nil
+}
+
+// Run читает поток и раздает события подписчикам до отмены контекста.
+// Если Redis недоступен, попытки повторяются, и чтение продолжается
+// с места разрыва, пока события остаются в потоке.
+func (b *Bus) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		err := b.read(ctx)
+		if err == nil || ctx.Err() != nil {
+			continue
+		}
+
+		logger.FromContext(ctx).Errorf("read banner changes error: %s", err.Error())
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// start запоминает номер последнего опубликованного события, чтобы
+// читать поток после него.
+func (b *Bus) start(ctx context.Context) error {
+	seq, err := b.rdb.Get(ctx, seqKey).Uint64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("get seq error: %w", err)
+	}
+
+	b.last = eventID(seq)
+
+	b.mu.Lock()
+	b.seq, b.started = seq, true
+	b.mu.Unlock()
+
+	return nil
+}
+
+// read читает очередную порцию событий после b.last и сдвигает b.last.
+func (b *Bus) read(ctx context.Context) error {
+	if b.last == "" {
+		if err := b.start(ctx); err != nil {
+			return err
+		}
+	}
+
+	streams, err := b.rdb.XRead(ctx, &redis.XReadArgs{ //nolint:exhaustruct
+		Streams: []string{streamKey, b.last},
+		Count:   readCount,
+		Block:   readBlock,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("xread error: %w", err)
+	}
+
+	for _, s := range streams {
+		for _, m := range s.Messages {
+			b.last = m.ID
+
+			e, err := decode(m)
+			if err != nil {
+				logger.FromContext(ctx).Errorf("decode banner change %s error: %s", m.ID, err.Error())
+
+				continue
+			}
+
+			b.dispatch(e)
+		}
+	}
+
+	return nil
+}
+
+func decode(m redis.XMessage) (Event, error) {
+	seqStr, _, _ := strings.Cut(m.ID, "-")
+
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if err != nil {
+		return Event{}, fmt.Errorf("parse id error: %w", err)
+	}
+
+	data, ok := m.Values["event"].(string)
+	if !ok {
+		return Event{}, errors.New("event field not found") //nolint:goerr113
+	}
+
+	var e Event
+
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		return Event{}, fmt.Errorf("unmarshal error: %w", err)
+	}
+
+	e.Seq = seq
+
+	return e, nil
+}
+
+func (b *Bus) dispatch(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq = max(b.seq, e.Seq)
+
+	for s := range b.subs {
+		if e.Seq <= s.after || !s.filter.Match(e) {
+			continue
+		}
+
+		select {
+		case s.ch <- e:
+		default:
+			b.drop(s, ErrSlowSubscriber)
+		}
+	}
+}
+
+// Subscribe подписывается на события после текущего. Seq подписки - номер
+// последнего разосланного события на момент подписки.
+func (b *Bus) Subscribe(filter Filter) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.subscribe(filter, 0)
+}
+
+// SubscribeAfter подписывается на события после события с идентификатором id
+// (см. EventID), в том числе выданным другой репликой. Пропущенные события,
+// подходящие под фильтр, читаются из потока и возвращаются вместе с подпиской.
+// Если id не выдавался или пропущенные события уже вытеснены из потока,
+// ok = false и пропущенные события неизвестны.
+func (b *Bus) SubscribeAfter(ctx context.Context, filter Filter, id string) (*Subscription, []Event, bool) {
+	after, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return b.Subscribe(filter), nil, false
+	}
+
+	published, err := b.rdb.Get(ctx, seqKey).Uint64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		logger.FromContext(ctx).Errorf("get banner changes seq error: %s", err.Error())
+	}
+
+	if err != nil || after > published {
+		return b.Subscribe(filter), nil, false
+	}
+
+	b.mu.Lock()
+
+	started := b.started
+	if !started {
+		after = 0
+	}
+
+	// События до after клиент уже получил, а события до s.seq реплика
+	// разослала до подписки: они читаются из потока.
+	s := b.subscribe(filter, after)
+
+	b.mu.Unlock()
+
+	// Реплика еще не знает, с какого события читает поток.
+	if !started {
+		return s, nil, false
+	}
+
+	if after >= s.seq {
+		return s, nil, true
+	}
+
+	msgs, err := b.rdb.XRange(ctx, streamKey, eventID(after+1), eventID(s.seq)).Result()
+	if err != nil {
+		logger.FromContext(ctx).Errorf("read missed banner changes error: %s", err.Error())
+
+		return s, nil, false
+	}
+
+	// Номера идут без пропусков, поэтому нехватка записей означает,
+	// что часть событий вытеснена из потока.
+	if uint64(len(msgs)) != s.seq-after {
+		return s, nil, false
+	}
+
+	var missed []Event
+
+	for _, m := range msgs {
+		e, err := decode(m)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("decode banner change %s error: %s", m.ID, err.Error())
+
+			continue
+		}
+
+		if filter.Match(e) {
+			missed = append(missed, e)
+		}
+	}
+
+	return s, missed, true
+}
+
+// EventID возвращает идентификатор события для SubscribeAfter.
+func (b *Bus) EventID(seq uint64) string {
+	return strconv.FormatUint(seq, 10)
+}
+
+// eventID - идентификатор записи события в потоке.
+func eventID(seq uint64) string {
+	return strconv.FormatUint(seq, 10) + "-0"
+}
+
+// subscribe вызывается под b.mu. Подписчик получает только события
+// с номером больше after.
+func (b *Bus) subscribe(filter Filter, after uint64) *Subscription {
+	s := &Subscription{
+		bus:    b,
+		filter: filter,
+		seq:    b.seq,
+		after:  after,
+		ch:     make(chan Event, b.size),
+		err:    nil,
+	}
+
+	b.subs[s] = struct{}{}
+
+	return s
+}
+
+// drop вызывается под b.mu.
+func (b *Bus) drop(s *Subscription, err error) {
+	if _, ok := b.subs[s]; !ok {
+		return
+	}
+
+	delete(b.subs, s)
+
+	s.err = err
+	close(s.ch)
+}
+
+type Subscription struct {
+	bus    *Bus
+	filter Filter
+	seq    uint64
+	after  uint64
+	ch     chan Event
+	err    error
+}
+
+func (s *Subscription) Seq() uint64 {
+	return s.seq
+}
+
+// C возвращает канал событий. Канал закрывается после Close или
+// отключения подписчика, причину отключения возвращает Err.
+func (s *Subscription) C() <-chan Event {
+	return s.ch
+}
+
+func (s *Subscription) Err() error {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	return s.err
+}
+
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	s.bus.drop(s, nil)
+}
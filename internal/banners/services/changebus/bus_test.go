@@ -0,0 +1,175 @@
+package changebus_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/services/changebus"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+// newBus создает шину реплики поверх общего Redis и запускает чтение потока.
+func newBus(t *testing.T, mr *miniredis.Miniredis, cfg config.Changes) *changebus.Bus {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()}) //nolint:exhaustruct
+
+	bus := changebus.New(ctx, rdb, cfg)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		bus.Run(ctx)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		rdb.Close()
+		<-done
+	})
+
+	return bus
+}
+
+func receive(t *testing.T, sub *changebus.Subscription) changebus.Event {
+	t.Helper()
+
+	select {
+	case e, ok := <-sub.C():
+		require.True(t, ok, "subscription closed: %v", sub.Err())
+
+		return e
+	case <-time.After(3 * time.Second):
+		t.Fatal("event not received")
+	}
+
+	return changebus.Event{} //nolint:exhaustruct
+}
+
+func TestBusReplicas(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	cfg := config.Changes{Buffer: 10, History: 100}
+
+	first, second := newBus(t, mr, cfg), newBus(t, mr, cfg)
+
+	all := second.Subscribe(changebus.Filter{})
+	defer all.Close()
+
+	tagged := first.Subscribe(changebus.Filter{FeatureID: 1, TagID: 2})
+	defer tagged.Close()
+
+	// Изменения, сделанные на одной реплике, получают подписчики всех реплик
+	// с общими номерами.
+	created, err := first.Publish(ctx, changebus.Event{Type: changebus.Created, Banner: models.Banner{ID: 1, FeatureID: 1, Tags: []int{2, 3}}}) //nolint:exhaustruct,lll
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), created.Seq)
+
+	_, err = second.Publish(ctx, changebus.Event{Type: changebus.Created, Banner: models.Banner{ID: 2, FeatureID: 1, Tags: []int{3}}}) //nolint:exhaustruct,lll
+	require.NoError(t, err)
+
+	deleted, err := second.Publish(ctx, changebus.Event{Type: changebus.Deleted, Banner: models.Banner{ID: 1, FeatureID: 1, Tags: []int{2, 3}}}) //nolint:exhaustruct,lll
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), deleted.Seq)
+
+	e := receive(t, tagged)
+	require.Equal(t, created.Seq, e.Seq)
+	require.Equal(t, int64(1), e.Banner.ID)
+	require.Equal(t, changebus.Created, e.Type)
+
+	e = receive(t, tagged)
+	require.Equal(t, changebus.Deleted, e.Type)
+	require.Equal(t, deleted.Seq, e.Seq)
+	require.WithinDuration(t, deleted.At, e.At, time.Millisecond)
+
+	for seq := uint64(1); seq <= 3; seq++ {
+		require.Equal(t, seq, receive(t, all).Seq)
+	}
+
+	require.Len(t, tagged.C(), 0)
+}
+
+func TestBusSlowSubscriber(t *testing.T) {
+	ctx := context.Background()
+	bus := newBus(t, miniredis.RunT(t), config.Changes{Buffer: 1, History: 100})
+
+	// Подписчик не читает канал, пока оба события не разосланы.
+	sub := bus.Subscribe(changebus.Filter{})
+
+	for id := int64(1); id <= 2; id++ {
+		_, err := bus.Publish(ctx, changebus.Event{Type: changebus.Created, Banner: models.Banner{ID: id}}) //nolint:exhaustruct
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool { return sub.Err() != nil }, 3*time.Second, 10*time.Millisecond)
+
+	_, ok := <-sub.C()
+	require.True(t, ok)
+
+	_, ok = <-sub.C()
+	require.False(t, ok)
+	require.ErrorIs(t, sub.Err(), changebus.ErrSlowSubscriber)
+
+	// Повторное закрытие не паникует и не сбрасывает причину.
+	sub.Close()
+	require.ErrorIs(t, sub.Err(), changebus.ErrSlowSubscriber)
+}
+
+func TestBusSubscribeAfter(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	cfg := config.Changes{Buffer: 10, History: 3}
+	first, second := newBus(t, mr, cfg), newBus(t, mr, cfg)
+	filter := changebus.Filter{FeatureID: 1}
+
+	// Реплика, на которую переподключается клиент, должна успеть прочитать события.
+	probe := second.Subscribe(changebus.Filter{})
+	defer probe.Close()
+
+	publish := func(e changebus.Event) changebus.Event {
+		e, err := first.Publish(ctx, e)
+		require.NoError(t, err)
+		require.Equal(t, e.Seq, receive(t, probe).Seq)
+
+		return e
+	}
+
+	created := publish(changebus.Event{Type: changebus.Created, Banner: models.Banner{ID: 1, FeatureID: 1}}) //nolint:exhaustruct
+	publish(changebus.Event{Type: changebus.Created, Banner: models.Banner{ID: 2, FeatureID: 2}})            //nolint:exhaustruct
+
+	// Баннер ушел из фичи: событие нужно подписчику старой фичи.
+	publish(changebus.Event{ //nolint:exhaustruct
+		Type:     changebus.Updated,
+		Banner:   models.Banner{ID: 1, FeatureID: 3},  //nolint:exhaustruct
+		Previous: &models.Banner{ID: 1, FeatureID: 1}, //nolint:exhaustruct
+	})
+
+	// Идентификатор, выданный первой репликой, продолжает поток на второй.
+	sub, missed, ok := second.SubscribeAfter(ctx, filter, first.EventID(created.Seq))
+	defer sub.Close()
+	require.True(t, ok)
+	require.Len(t, missed, 1)
+	require.Equal(t, changebus.Updated, missed[0].Type)
+	require.Equal(t, uint64(3), missed[0].Seq)
+	require.Equal(t, uint64(3), sub.Seq())
+
+	deleted := publish(changebus.Event{Type: changebus.Deleted, Banner: models.Banner{ID: 1, FeatureID: 1}}) //nolint:exhaustruct
+	require.Equal(t, deleted.Seq, receive(t, sub).Seq)
+
+	// Первые события уже вытеснены из потока.
+	publish(changebus.Event{Type: changebus.Created, Banner: models.Banner{ID: 3, FeatureID: 1}}) //nolint:exhaustruct
+
+	for _, id := range []string{second.EventID(0), "other-1", second.EventID(100)} {
+		sub, _, ok := second.SubscribeAfter(ctx, filter, id)
+		require.False(t, ok, id)
+		sub.Close()
+	}
+}
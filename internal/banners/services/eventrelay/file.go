@@ -0,0 +1,69 @@
+package eventrelay
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+)
+
+// WriterPublisher пишет события в поток по одному JSON на строку.
+type WriterPublisher struct {
+	w    io.Writer
+	file *os.File
+}
+
+func NewWriter(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{w: w}
+}
+
+// NewFile дописывает события в файл path. Пустой путь или "-" - stdout.
+func NewFile(path string) (*WriterPublisher, error) {
+	if path == "" || path == "-" {
+		return NewWriter(os.Stdout), nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644) //nolint:gomnd
+	if err != nil {
+		return nil, fmt.Errorf("open events file error: %w", err)
+	}
+
+	return &WriterPublisher{w: f, file: f}, nil
+}
+
+// Publish сбрасывает файл на диск, чтобы события не потерялись после
+// того, как будут отмечены опубликованными.
+func (wp *WriterPublisher) Publish(_ context.Context, events []models.BannerEvent) error {
+	bw := bufio.NewWriter(wp.w)
+	enc := json.NewEncoder(bw)
+
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("encode event error: %w", err)
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write events error: %w", err)
+	}
+
+	if wp.file != nil {
+		if err := wp.file.Sync(); err != nil {
+			return fmt.Errorf("sync events file error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (wp *WriterPublisher) Close() error {
+	if wp.file == nil {
+		return nil
+	}
+
+	return wp.file.Close() //nolint:wrapcheck
+}
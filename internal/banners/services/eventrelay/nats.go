@@ -0,0 +1,88 @@
+package eventrelay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSPublisher публикует события в NATS JetStream в тему <Subject>.<event>.
+// Заголовок Nats-Msg-Id с id события позволяет JetStream отбросить повтор
+// в пределах окна дедупликации потока.
+type NATSPublisher struct {
+	nc  *nats.Conn
+	js  jetstream.JetStream
+	cfg config.EventsNATS
+}
+
+func NewNATS(ctx context.Context, cfg config.EventsNATS) (*NATSPublisher, error) {
+	nc, err := nats.Connect(cfg.URL, nats.Name("banners-events"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("nats connect error: %w", err)
+	}
+
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+
+		return nil, fmt.Errorf("jetstream initializing error: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	// Настройки существующего потока не меняются.
+	_, err = js.CreateStream(ctx, jetstream.StreamConfig{
+		Name:     cfg.Stream,
+		Subjects: []string{cfg.Subject + ".>"},
+	})
+	if err != nil && !errors.Is(err, jetstream.ErrStreamNameAlreadyInUse) {
+		nc.Close()
+
+		return nil, fmt.Errorf("create stream error: %w", err)
+	}
+
+	return &NATSPublisher{nc: nc, js: js, cfg: cfg}, nil
+}
+
+// Publish отправляет события по одному, дожидаясь подтверждения, чтобы
+// сохранить порядок.
+func (np *NATSPublisher) Publish(ctx context.Context, events []models.BannerEvent) error {
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("marshal event error: %w", err)
+		}
+
+		msg := nats.NewMsg(np.cfg.Subject + "." + e.Event)
+		msg.Data = data
+
+		if err := np.publish(ctx, msg, strconv.FormatInt(e.ID, 10)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (np *NATSPublisher) publish(ctx context.Context, msg *nats.Msg, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, np.cfg.Timeout)
+	defer cancel()
+
+	if _, err := np.js.PublishMsg(ctx, msg, jetstream.WithMsgID(id)); err != nil {
+		return fmt.Errorf("publish event %s error: %w", id, err)
+	}
+
+	return nil
+}
+
+func (np *NATSPublisher) Close() error {
+	return np.nc.Drain() //nolint:wrapcheck
+}
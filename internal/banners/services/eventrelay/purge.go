@@ -0,0 +1,57 @@
+package eventrelay
+
+import (
+	"context"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+)
+
+const purgeInterval = time.Hour
+
+// Purger удаляет старые события из banner_events. Он работает на каждой
+// реплике независимо от публикации: события записываются всегда, и без
+// очистки таблица росла бы, пока публикация выключена на всех репликах.
+type Purger struct {
+	repo      Repository
+	retention time.Duration
+}
+
+func NewPurger(repo Repository, cfg config.Events) *Purger {
+	return &Purger{
+		repo:      repo,
+		retention: cfg.Retention,
+	}
+}
+
+// Run удаляет события раз в purgeInterval, пока не отменен ctx.
+func (p *Purger) Run(ctx context.Context) {
+	if p.retention == 0 {
+		return
+	}
+
+	purge := time.NewTicker(purgeInterval)
+	defer purge.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-purge.C:
+			p.Purge(ctx)
+		}
+	}
+}
+
+// Purge удаляет события старше retention, в том числе неопубликованные.
+func (p *Purger) Purge(ctx context.Context) {
+	n, err := p.repo.PurgeEvents(ctx, time.Now().Add(-p.retention))
+	if err != nil {
+		logger.FromContext(ctx).Errorf("purge banner events error: %s", err.Error())
+
+		return
+	}
+
+	logger.FromContext(ctx).Debugf("purged %d banner events", n)
+}
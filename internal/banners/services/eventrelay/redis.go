@@ -0,0 +1,49 @@
+package eventrelay
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisPublisher добавляет события в поток Redis Streams. Команды пакета
+// отправляются одним конвейером и выполняются по порядку.
+type RedisPublisher struct {
+	rdb redis.UniversalClient
+	cfg config.EventsRedis
+}
+
+func NewRedis(rdb redis.UniversalClient, cfg config.EventsRedis) *RedisPublisher {
+	return &RedisPublisher{rdb: rdb, cfg: cfg}
+}
+
+func (rp *RedisPublisher) Publish(ctx context.Context, events []models.BannerEvent) error {
+	pipe := rp.rdb.Pipeline()
+
+	for _, e := range events {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: rp.cfg.Stream,
+			MaxLen: rp.cfg.MaxLen,
+			Approx: rp.cfg.MaxLen > 0,
+			Values: []interface{}{
+				"event_id", e.ID,
+				"banner_id", e.BannerID,
+				"event", e.Event,
+				"data", string(e.Data),
+			},
+		})
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("xadd error: %w", err)
+	}
+
+	return nil
+}
+
+func (rp *RedisPublisher) Close() error {
+	return rp.rdb.Close() //nolint:wrapcheck
+}
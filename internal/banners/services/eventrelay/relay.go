@@ -0,0 +1,101 @@
+package eventrelay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+)
+
+const (
+	PublisherStdout = "stdout"
+	PublisherFile   = "file"
+	PublisherRedis  = "redis"
+	PublisherNATS   = "nats"
+)
+
+var ErrUnknownPublisher = errors.New("unknown event publisher")
+
+// Publisher публикует пакет событий по порядку. При ошибке пакет публикуется
+// повторно целиком, поэтому получатели отбрасывают повторы по BannerEvent.ID.
+type Publisher interface {
+	Publish(ctx context.Context, events []models.BannerEvent) error
+	Close() error
+}
+
+type Repository interface {
+	RelayEvents(ctx context.Context, limit int, publish func([]models.BannerEvent) error) (int, error)
+	PurgeEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Relay переносит события из banner_events в Publisher.
+type Relay struct {
+	repo Repository
+	pub  Publisher
+	cfg  config.Events
+}
+
+func New(repo Repository, pub Publisher, cfg config.Events) *Relay {
+	return &Relay{
+		repo: repo,
+		pub:  pub,
+		cfg:  cfg,
+	}
+}
+
+// Run публикует события, пока не отменен ctx, затем закрывает Publisher.
+// Полный пакет означает, что события еще остались, и следующий пакет
+// берется без ожидания.
+func (r *Relay) Run(ctx context.Context) {
+	defer func() {
+		if err := r.pub.Close(); err != nil {
+			logger.FromContext(ctx).Errorf("close event publisher error: %s", err.Error())
+		}
+	}()
+
+	poll := time.NewTicker(r.cfg.PollInterval)
+	defer poll.Stop()
+
+	for {
+		for {
+			n, err := r.RelayPending(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.FromContext(ctx).Errorf("relay events error: %s", err.Error())
+				}
+
+				break
+			}
+
+			if n < r.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		}
+	}
+}
+
+// RelayPending публикует один пакет событий и возвращает его размер.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	n, err := r.repo.RelayEvents(ctx, r.cfg.BatchSize, func(events []models.BannerEvent) error {
+		if err := r.pub.Publish(ctx, events); err != nil {
+			return fmt.Errorf("publish error: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("relay events error: %w", err)
+	}
+
+	return n, nil
+}
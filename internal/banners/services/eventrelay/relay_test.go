@@ -0,0 +1,131 @@
+package eventrelay_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/services/eventrelay"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+var errPublish = errors.New("publish failed")
+
+// repoMock отмечает события опубликованными, только если publish успешен.
+type repoMock struct {
+	eventrelay.Repository
+
+	events []models.BannerEvent
+	purged []time.Time
+}
+
+func (r *repoMock) PurgeEvents(_ context.Context, before time.Time) (int64, error) {
+	r.purged = append(r.purged, before)
+
+	return 0, nil
+}
+
+func (r *repoMock) RelayEvents(_ context.Context, limit int, publish func([]models.BannerEvent) error) (int, error) {
+	batch := r.events[:min(limit, len(r.events))]
+	if len(batch) == 0 {
+		return 0, nil
+	}
+
+	if err := publish(batch); err != nil {
+		return 0, err
+	}
+
+	r.events = r.events[len(batch):]
+
+	return len(batch), nil
+}
+
+type failingPublisher struct {
+	eventrelay.Publisher
+}
+
+func (failingPublisher) Publish(context.Context, []models.BannerEvent) error {
+	return errPublish
+}
+
+func testEvents() []models.BannerEvent {
+	return []models.BannerEvent{
+		{ID: 1, BannerID: 3, Event: models.EventCreated, CreatedAt: time.Now(), Data: json.RawMessage(`{"event":"created"}`)},
+		{ID: 2, BannerID: 3, Event: models.EventUpdated, CreatedAt: time.Now(), Data: json.RawMessage(`{"event":"updated"}`)},
+		{ID: 3, BannerID: 1, Event: models.EventDeleted, CreatedAt: time.Now(), Data: json.RawMessage(`{"event":"deleted"}`)},
+	}
+}
+
+func TestRelayPending(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.Events{BatchSize: 2} //nolint:exhaustruct
+
+	repo := &repoMock{events: testEvents()} //nolint:exhaustruct
+
+	n, err := eventrelay.New(repo, failingPublisher{}, cfg).RelayPending(ctx) //nolint:exhaustruct
+	require.ErrorIs(t, err, errPublish)
+	require.Zero(t, n)
+	require.Len(t, repo.events, 3)
+
+	var buf bytes.Buffer
+
+	r := eventrelay.New(repo, eventrelay.NewWriter(&buf), cfg)
+
+	for _, want := range []int{2, 1, 0} {
+		n, err := r.RelayPending(ctx)
+		require.NoError(t, err)
+		require.Equal(t, want, n)
+	}
+
+	var ids []int64
+
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var e models.BannerEvent
+
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &e))
+
+		ids = append(ids, e.ID)
+	}
+
+	require.Equal(t, []int64{1, 2, 3}, ids)
+}
+
+func TestRedisPublisher(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()}) //nolint:exhaustruct
+	pub := eventrelay.NewRedis(rdb, config.EventsRedis{Stream: "banners:events"})
+
+	require.NoError(t, pub.Publish(ctx, testEvents()))
+
+	msgs, err := rdb.XRange(ctx, "banners:events", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, msgs, 3)
+
+	for i, e := range testEvents() {
+		require.Equal(t, e.Event, msgs[i].Values["event"])
+		require.Equal(t, string(e.Data), msgs[i].Values["data"])
+	}
+
+	require.NoError(t, pub.Close())
+}
+
+func TestPurger(t *testing.T) {
+	repo := &repoMock{} //nolint:exhaustruct
+
+	// Очистка не зависит от включения публикации.
+	eventrelay.NewPurger(repo, config.Events{Enabled: false, Retention: time.Hour}).Purge(context.Background()) //nolint:exhaustruct
+
+	require.Len(t, repo.purged, 1)
+	require.WithinDuration(t, time.Now().Add(-time.Hour), repo.purged[0], 5*time.Second)
+}
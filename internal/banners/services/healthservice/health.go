@@ -0,0 +1,175 @@
+package healthservice
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+)
+
+const (
+	checkPostgres   = "postgres"
+	checkRedis      = "redis"
+	checkMigrations = "migrations"
+	checkCache      = "cache"
+
+	checkTimeout = time.Second
+)
+
+type Database interface {
+	Ping(context.Context) error
+	MigrationVersion(context.Context) (int64, error)
+}
+
+type Cache interface {
+	Ping(context.Context) error
+	IsWarm(context.Context) (bool, error)
+}
+
+// HealthService проверяет готовность экземпляра принимать трафик.
+// Если включен автоматический выключатель, сервис умеет работать без одной
+// из зависимостей, поэтому для готовности достаточно доступности БД или Redis.
+type HealthService struct {
+	db       Database
+	cache    Cache
+	version  int64
+	degraded bool
+	draining *atomic.Bool
+}
+
+func New(db Database, cache Cache, cfg config.Config) *HealthService {
+	return &HealthService{
+		db:       db,
+		cache:    cache,
+		version:  int64(cfg.PostgresDB.Version),
+		degraded: cfg.Breaker.Enabled,
+		draining: new(atomic.Bool),
+	}
+}
+
+// Drain переводит проверку готовности в состояние отказа, чтобы балансировщик
+// перестал направлять новые запросы до закрытия соединений.
+func (hs *HealthService) Drain() {
+	hs.draining.Store(true)
+}
+
+func (hs *HealthService) Ready(ctx context.Context) ReadyResponse {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var (
+		wg                                  sync.WaitGroup
+		postgres, redis, migrations, warmth Check
+	)
+
+	wg.Add(2) //nolint:gomnd
+
+	go func() {
+		defer wg.Done()
+
+		postgres = check(ctx, hs.db.Ping)
+		if postgres.Status == StatusOK {
+			migrations = hs.checkMigrations(ctx)
+		} else {
+			migrations = Check{Status: StatusFail, Error: "postgres unavailable"} //nolint:exhaustruct
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		redis = check(ctx, hs.cache.Ping)
+		if redis.Status == StatusOK {
+			warmth = hs.checkWarm(ctx)
+		} else {
+			warmth = Check{Status: StatusFail, Error: "redis unavailable"} //nolint:exhaustruct
+		}
+	}()
+
+	wg.Wait()
+
+	resp := ReadyResponse{
+		Draining: hs.draining.Load(),
+		Checks: map[string]Check{
+			checkPostgres:   postgres,
+			checkRedis:      redis,
+			checkMigrations: migrations,
+			checkCache:      warmth,
+		},
+	}
+
+	dbOK := postgres.Status == StatusOK && migrations.Status == StatusOK
+	cacheOK := redis.Status == StatusOK
+
+	// Доступная БД со старой схемой означает, что миграции еще не применены:
+	// такой экземпляр не готов и в режиме деградации.
+	schemaOK := postgres.Status != StatusOK || migrations.Status == StatusOK
+
+	// Прогрев кэша не влияет на готовность: при промахе баннер берется из БД.
+	if hs.degraded {
+		resp.Ready = (dbOK || cacheOK) && schemaOK
+	} else {
+		resp.Ready = dbOK && cacheOK
+	}
+
+	resp.Ready = resp.Ready && !resp.Draining
+
+	return resp
+}
+
+func (hs *HealthService) checkMigrations(ctx context.Context) Check {
+	var version int64
+
+	c := check(ctx, func(ctx context.Context) error {
+		var err error
+
+		version, err = hs.db.MigrationVersion(ctx)
+
+		return err //nolint:wrapcheck
+	})
+	if c.Status != StatusOK {
+		return c
+	}
+
+	c.Version = &version
+
+	if version < hs.version {
+		c.Status = StatusFail
+		c.Error = fmt.Sprintf("expected migration version %d", hs.version)
+	}
+
+	return c
+}
+
+func (hs *HealthService) checkWarm(ctx context.Context) Check {
+	var warm bool
+
+	c := check(ctx, func(ctx context.Context) error {
+		var err error
+
+		warm, err = hs.cache.IsWarm(ctx)
+
+		return err //nolint:wrapcheck
+	})
+	if c.Status == StatusOK {
+		c.Warm = &warm
+	}
+
+	return c
+}
+
+func check(ctx context.Context, fn func(context.Context) error) Check {
+	start := time.Now()
+	err := fn(ctx)
+	c := Check{Status: StatusOK, Latency: time.Since(start)} //nolint:exhaustruct
+
+	if err != nil {
+		c.Status = StatusFail
+		c.Error = err.Error()
+	}
+
+	return c
+}
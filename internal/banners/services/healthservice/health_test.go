@@ -0,0 +1,73 @@
+package healthservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Leopold1975/banners_control/internal/banners/services/healthservice"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+var errDown = errors.New("down")
+
+type dbMock struct {
+	err     error
+	version int64
+}
+
+func (d dbMock) Ping(context.Context) error {
+	return d.err
+}
+
+func (d dbMock) MigrationVersion(context.Context) (int64, error) {
+	return d.version, d.err
+}
+
+type cacheMock struct {
+	err error
+}
+
+func (c cacheMock) Ping(context.Context) error {
+	return c.err
+}
+
+func (c cacheMock) IsWarm(context.Context) (bool, error) {
+	return true, c.err
+}
+
+func TestReady(t *testing.T) {
+	tests := []struct {
+		name     string
+		degraded bool
+		db       dbMock
+		cache    cacheMock
+		ready    bool
+	}{
+		{name: "all up", db: dbMock{version: 2}, ready: true},                                                  //nolint:exhaustruct
+		{name: "redis down", db: dbMock{version: 2}, cache: cacheMock{err: errDown}},                           //nolint:exhaustruct
+		{name: "degraded, redis down", degraded: true, db: dbMock{version: 2}, ready: true},                    //nolint:exhaustruct
+		{name: "degraded, db down", degraded: true, db: dbMock{err: errDown}, ready: true},                     //nolint:exhaustruct
+		{name: "degraded, migrations pending", degraded: true, db: dbMock{version: 1}},                         //nolint:exhaustruct
+		{name: "migrations pending", db: dbMock{version: 1}},                                                   //nolint:exhaustruct
+		{name: "degraded, all down", degraded: true, db: dbMock{err: errDown}, cache: cacheMock{err: errDown}}, //nolint:exhaustruct
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg config.Config
+
+			cfg.PostgresDB.Version = 2
+			cfg.Breaker.Enabled = tt.degraded
+
+			hs := healthservice.New(tt.db, tt.cache, cfg)
+
+			require.Equal(t, tt.ready, hs.Ready(context.Background()).Ready)
+
+			hs.Drain()
+
+			require.False(t, hs.Ready(context.Background()).Ready)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package healthservice
+
+import "time"
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+type Check struct {
+	Status  string
+	Error   string
+	Latency time.Duration
+	// Version заполняется для проверки миграций, Warm - для проверки прогрева кэша.
+	Version *int64
+	Warm    *bool
+}
+
+type ReadyResponse struct {
+	Ready    bool
+	Draining bool
+	Checks   map[string]Check
+}
@@ -0,0 +1,8 @@
+package statsservice
+
+import "errors"
+
+var (
+	ErrInvalidEvent = errors.New("invalid event")
+	ErrInvalidRange = errors.New("invalid time range")
+)
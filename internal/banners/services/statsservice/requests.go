@@ -0,0 +1,10 @@
+package statsservice
+
+import "time"
+
+type GetStatsRequest struct {
+	BannerID int64
+	// From и To - границы периода, по умолчанию сутки до текущего времени.
+	From time.Time
+	To   time.Time
+}
@@ -0,0 +1,241 @@
+package statsservice
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/statsrepo"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+)
+
+const (
+	defaultRange = 24 * time.Hour
+	// shutdownTimeout - сколько дается на передачу счетчиков при остановке.
+	shutdownTimeout = 5 * time.Second
+)
+
+// Counters - общие счетчики реплик, из которых счетчики переносятся в Repository.
+type Counters interface {
+	AddCounters(context.Context, []models.StatsCounter) error
+	TakeCounters(context.Context) ([]models.StatsCounter, error)
+}
+
+type Repository interface {
+	AddCounters(context.Context, []models.StatsCounter) error
+	GetTagStats(context.Context, statsrepo.GetStatsRequest) ([]models.TagStats, error)
+}
+
+type counterKey struct {
+	bannerID int64
+	tagID    int
+	hour     int64
+}
+
+type StatsService struct {
+	counters Counters
+	repo     Repository
+	cfg      config.Stats
+
+	mu  sync.Mutex
+	buf map[counterKey]*models.StatsCounter
+}
+
+func New(counters Counters, repo Repository, cfg config.Stats) *StatsService {
+	return &StatsService{
+		counters: counters,
+		repo:     repo,
+		cfg:      cfg,
+		buf:      make(map[counterKey]*models.StatsCounter),
+	}
+}
+
+// Impression учитывает показ баннера пользователю с тэгом tagID.
+func (ss *StatsService) Impression(bannerID int64, tagID int) {
+	ss.add(bannerID, tagID, 1, 0)
+}
+
+// Click учитывает клик по баннеру пользователя с тэгом tagID.
+func (ss *StatsService) Click(_ context.Context, bannerID int64, tagID int) error {
+	if bannerID <= 0 || tagID < 0 {
+		return fmt.Errorf("%w: banner_id must be positive, tag_id non-negative", ErrInvalidEvent)
+	}
+
+	ss.add(bannerID, tagID, 0, 1)
+
+	return nil
+}
+
+// add копит счетчики в памяти до следующей передачи в Counters. Без учета
+// статистики счетчики не копятся.
+func (ss *StatsService) add(bannerID int64, tagID int, impressions, clicks int64) {
+	if !ss.cfg.Enabled {
+		return
+	}
+
+	hour := time.Now().UTC().Truncate(time.Hour)
+	k := counterKey{bannerID: bannerID, tagID: tagID, hour: hour.Unix()}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	c, ok := ss.buf[k]
+	if !ok {
+		c = &models.StatsCounter{BannerID: bannerID, TagID: tagID, Hour: hour} //nolint:exhaustruct
+		ss.buf[k] = c
+	}
+
+	c.Impressions += impressions
+	c.Clicks += clicks
+}
+
+// GetStats возвращает статистику баннера за часы периода. Счетчики,
+// еще не перенесенные в Repository, не учитываются.
+func (ss *StatsService) GetStats(ctx context.Context, req GetStatsRequest) (models.BannerStats, error) {
+	if req.To.IsZero() {
+		req.To = time.Now()
+	}
+
+	if req.From.IsZero() {
+		req.From = req.To.Add(-defaultRange)
+	}
+
+	from, to := req.From.UTC().Truncate(time.Hour), req.To.UTC().Truncate(time.Hour)
+	// Текущий час входит в период, если до него дошел to.
+	if to.Before(req.To) {
+		to = to.Add(time.Hour)
+	}
+
+	if !from.Before(to) {
+		return models.BannerStats{}, fmt.Errorf("%w: from must be before to", ErrInvalidRange)
+	}
+
+	tags, err := ss.repo.GetTagStats(ctx, statsrepo.GetStatsRequest{BannerID: req.BannerID, From: from, To: to})
+	if err != nil {
+		return models.BannerStats{}, fmt.Errorf("get tag stats error: %w", err)
+	}
+
+	stats := models.BannerStats{ //nolint:exhaustruct
+		BannerID: req.BannerID,
+		From:     from,
+		To:       to,
+		Tags:     tags,
+	}
+
+	for i := range tags {
+		tags[i].CTR = ctr(tags[i].Clicks, tags[i].Impressions)
+		stats.Impressions += tags[i].Impressions
+		stats.Clicks += tags[i].Clicks
+	}
+
+	stats.CTR = ctr(stats.Clicks, stats.Impressions)
+
+	return stats, nil
+}
+
+func ctr(clicks, impressions int64) float64 {
+	if impressions == 0 {
+		return 0
+	}
+
+	return float64(clicks) / float64(impressions)
+}
+
+// Run передает накопленные счетчики в Counters и переносит общие счетчики
+// в Repository, пока не отменен ctx. При остановке счетчики реплики
+// передаются в последний раз.
+func (ss *StatsService) Run(ctx context.Context) {
+	push := time.NewTicker(ss.cfg.PushInterval)
+	defer push.Stop()
+
+	flush := time.NewTicker(ss.cfg.FlushInterval)
+	defer flush.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+			defer cancel()
+
+			if err := ss.Push(ctx); err != nil {
+				logger.FromContext(ctx).Errorf("push stats error: %s", err.Error())
+			}
+
+			return
+		case <-push.C:
+			if err := ss.Push(ctx); err != nil {
+				logger.FromContext(ctx).Errorf("push stats error: %s", err.Error())
+			}
+		case <-flush.C:
+			if err := ss.Flush(ctx); err != nil {
+				logger.FromContext(ctx).Errorf("flush stats error: %s", err.Error())
+			}
+		}
+	}
+}
+
+// Push передает накопленные счетчики в Counters. Если передать не удалось,
+// счетчики остаются до следующей попытки.
+func (ss *StatsService) Push(ctx context.Context) error {
+	ss.mu.Lock()
+	buf := ss.buf
+	ss.buf = make(map[counterKey]*models.StatsCounter, len(buf))
+	ss.mu.Unlock()
+
+	if len(buf) == 0 {
+		return nil
+	}
+
+	counters := make([]models.StatsCounter, 0, len(buf))
+	for _, c := range buf {
+		counters = append(counters, *c)
+	}
+
+	if err := ss.counters.AddCounters(ctx, counters); err != nil {
+		ss.restore(buf)
+
+		return fmt.Errorf("add counters error: %w", err)
+	}
+
+	return nil
+}
+
+func (ss *StatsService) restore(buf map[counterKey]*models.StatsCounter) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	for k, c := range buf {
+		if cur, ok := ss.buf[k]; ok {
+			cur.Impressions += c.Impressions
+			cur.Clicks += c.Clicks
+
+			continue
+		}
+
+		ss.buf[k] = c
+	}
+}
+
+// Flush переносит общие счетчики в Repository. Если сохранить их не удалось,
+// они возвращаются в Counters; счетчики, не вернувшиеся и туда, теряются.
+func (ss *StatsService) Flush(ctx context.Context) error {
+	counters, err := ss.counters.TakeCounters(ctx)
+	if len(counters) != 0 {
+		if err := ss.repo.AddCounters(ctx, counters); err != nil {
+			if err := ss.counters.AddCounters(ctx, counters); err != nil {
+				logger.FromContext(ctx).Errorf("return %d stats counters error: %s", len(counters), err.Error())
+			}
+
+			return fmt.Errorf("save counters error: %w", err)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("take counters error: %w", err)
+	}
+
+	return nil
+}
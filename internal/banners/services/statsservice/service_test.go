@@ -0,0 +1,92 @@
+package statsservice_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/statsrepo"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/statsrepo/redis"
+	"github.com/Leopold1975/banners_control/internal/banners/services/statsservice"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+// repoMock суммирует счетчики по баннеру и тэгу, как banner_stats за период.
+type repoMock struct {
+	fail  bool
+	stats map[[2]int64]models.TagStats
+}
+
+func (r *repoMock) AddCounters(_ context.Context, counters []models.StatsCounter) error {
+	if r.fail {
+		return errUnavailable
+	}
+
+	for _, c := range counters {
+		k := [2]int64{c.BannerID, int64(c.TagID)}
+		t := r.stats[k]
+		t.TagID, t.Impressions, t.Clicks = c.TagID, t.Impressions+c.Impressions, t.Clicks+c.Clicks
+		r.stats[k] = t
+	}
+
+	return nil
+}
+
+func (r *repoMock) GetTagStats(_ context.Context, req statsrepo.GetStatsRequest) ([]models.TagStats, error) {
+	var tags []models.TagStats
+
+	for k, t := range r.stats {
+		if k[0] == req.BannerID {
+			tags = append(tags, t)
+		}
+	}
+
+	return tags, nil
+}
+
+func TestStats(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+
+	counters, err := redis.New(ctx, config.RedisCache{Addr: mr.Addr()}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	repo := &repoMock{stats: make(map[[2]int64]models.TagStats)}        //nolint:exhaustruct
+	ss := statsservice.New(counters, repo, config.Stats{Enabled: true}) //nolint:exhaustruct
+
+	for range 4 {
+		ss.Impression(1, 2)
+	}
+
+	require.NoError(t, ss.Click(ctx, 1, 2))
+	require.ErrorIs(t, ss.Click(ctx, 0, 2), statsservice.ErrInvalidEvent)
+
+	require.NoError(t, ss.Push(ctx))
+
+	// Неудачный перенос возвращает счетчики в Redis.
+	repo.fail = true
+	require.ErrorIs(t, ss.Flush(ctx), errUnavailable)
+
+	repo.fail = false
+	require.NoError(t, ss.Flush(ctx))
+	require.NoError(t, ss.Flush(ctx))
+
+	stats, err := ss.GetStats(ctx, statsservice.GetStatsRequest{BannerID: 1}) //nolint:exhaustruct
+	require.NoError(t, err)
+	require.Equal(t, int64(4), stats.Impressions)
+	require.Equal(t, int64(1), stats.Clicks)
+	require.InDelta(t, 0.25, stats.CTR, 1e-9)
+	require.Len(t, stats.Tags, 1)
+	require.Equal(t, 2, stats.Tags[0].TagID)
+
+	now := time.Now()
+
+	_, err = ss.GetStats(ctx, statsservice.GetStatsRequest{BannerID: 1, From: now, To: now.Add(-time.Hour)})
+	require.ErrorIs(t, err, statsservice.ErrInvalidRange)
+}
@@ -0,0 +1,229 @@
+package webhookservice
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/webhookrepo"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+)
+
+// Заголовки запроса вебхука.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	purgeInterval = time.Hour
+	// releaseTimeout ограничивает отмену прерванной попытки при остановке.
+	releaseTimeout = 5 * time.Second
+	// maxResponseSize - сколько читается из ответа, чтобы переиспользовать соединение.
+	maxResponseSize = 64 << 10
+)
+
+// Sign возвращает подпись тела запроса вебхука: HMAC-SHA256 секретом
+// от строки "<timestamp>.<body>". Получатель сверяет подпись и отвергает
+// запросы со старой меткой времени, чтобы их нельзя было повторить.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10))) //nolint:errcheck
+	mac.Write([]byte("."))                              //nolint:errcheck
+	mac.Write(body)                                     //nolint:errcheck
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Run отправляет доставки, пока не отменен ctx. Полный пакет означает,
+// что доставки еще остались, и следующий пакет берется без ожидания.
+func (ws *WebhookService) Run(ctx context.Context) {
+	poll := time.NewTicker(ws.cfg.PollInterval)
+	defer poll.Stop()
+
+	purge := time.NewTicker(purgeInterval)
+	defer purge.Stop()
+
+	for {
+		for {
+			n, err := ws.DeliverPending(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.FromContext(ctx).Errorf("deliver webhooks error: %s", err.Error())
+				}
+
+				break
+			}
+
+			if n < ws.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		case <-purge.C:
+			ws.purge(ctx)
+		}
+	}
+}
+
+// DeliverPending отправляет один пакет доставок и возвращает его размер.
+// В пакете не бывает двух доставок одного баннера на один вебхук, поэтому
+// доставки отправляются параллельно без нарушения порядка.
+func (ws *WebhookService) DeliverPending(ctx context.Context) (int, error) {
+	tasks, err := ws.repo.ClaimDeliveries(ctx, ws.cfg.BatchSize, ws.lease())
+	if err != nil {
+		return 0, fmt.Errorf("claim deliveries error: %w", err)
+	}
+
+	sem := make(chan struct{}, max(ws.cfg.Concurrency, 1))
+
+	var wg sync.WaitGroup
+
+	for _, t := range tasks {
+		sem <- struct{}{}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			ws.deliver(ctx, t)
+		}()
+	}
+
+	wg.Wait()
+
+	return len(tasks), nil
+}
+
+// lease - на сколько захватываются доставки пакета: пакет отправляется
+// волнами по Concurrency запросов, каждая не дольше Timeout, с запасом в одну волну.
+func (ws *WebhookService) lease() time.Duration {
+	concurrency := max(ws.cfg.Concurrency, 1)
+	waves := (ws.cfg.BatchSize + concurrency - 1) / concurrency
+
+	return ws.cfg.Timeout * time.Duration(waves+1)
+}
+
+func (ws *WebhookService) deliver(ctx context.Context, t webhookrepo.Task) {
+	d := t.Delivery
+	lg := logger.FromContext(ctx).With("webhook_id", d.WebhookID, "delivery_id", d.ID, "attempt", d.Attempts)
+
+	code, err := ws.send(ctx, t)
+
+	// При остановке результат не сохраняется, а попытка, засчитанная при
+	// захвате, отменяется. Если отменить не удалось, доставка повторится
+	// после истечения захвата.
+	if ctx.Err() != nil {
+		ws.release(ctx, d.ID)
+
+		return
+	}
+
+	now := time.Now()
+	d.ResponseCode = code
+
+	switch {
+	case err == nil:
+		d.Status, d.DeliveredAt, d.LastError = models.DeliveryDelivered, now, ""
+	case d.Attempts >= ws.cfg.MaxAttempts:
+		d.Status, d.LastError = models.DeliveryFailed, err.Error()
+
+		lg.Warnf("webhook delivery failed: %s", err.Error())
+	default:
+		d.NextAttemptAt, d.LastError = now.Add(ws.backoff(d.Attempts)), err.Error()
+
+		lg.Infof("webhook delivery error, retry at %s: %s", d.NextAttemptAt.Format(time.RFC3339), err.Error())
+	}
+
+	if err := ws.repo.SaveDelivery(ctx, d); err != nil {
+		lg.Errorf("save webhook delivery error: %s", err.Error())
+	}
+}
+
+// release отменяет прерванную попытку. Контекст уже отменен, поэтому запрос
+// выполняется с собственным ограничением по времени.
+func (ws *WebhookService) release(ctx context.Context, id int64) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+	defer cancel()
+
+	if err := ws.repo.ReleaseDelivery(ctx, id); err != nil {
+		logger.FromContext(ctx).Errorf("release webhook delivery error: %s", err.Error())
+	}
+}
+
+// send отправляет доставку и возвращает код ответа. Ответ вне 2xx - ошибка.
+func (ws *WebhookService) send(ctx context.Context, t webhookrepo.Task) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(t.Delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("new request error: %w", err)
+	}
+
+	ts := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "banners-webhooks")
+	req.Header.Set(HeaderEvent, t.Delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(t.Delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, Sign(t.Secret, ts, t.Delivery.Payload))
+
+	resp, err := ws.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("send error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize)) //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode) //nolint:goerr113
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff возвращает паузу после attempt неудачных попыток: MinBackoff,
+// удвоенная за каждую попытку после первой, но не больше MaxBackoff.
+func (ws *WebhookService) backoff(attempt int) time.Duration {
+	d := ws.cfg.MinBackoff
+
+	for i := 1; i < attempt && d < ws.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	return min(d, ws.cfg.MaxBackoff)
+}
+
+func (ws *WebhookService) purge(ctx context.Context) {
+	if ws.cfg.Retention == 0 {
+		return
+	}
+
+	n, err := ws.repo.PurgeDeliveries(ctx, time.Now().Add(-ws.cfg.Retention))
+	if err != nil {
+		logger.FromContext(ctx).Errorf("purge webhook deliveries error: %s", err.Error())
+
+		return
+	}
+
+	logger.FromContext(ctx).Debugf("purged %d webhook deliveries", n)
+}
@@ -0,0 +1,134 @@
+package webhookservice_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/webhookrepo"
+	"github.com/Leopold1975/banners_control/internal/banners/services/webhookservice"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+type repoMock struct {
+	webhookservice.Repository
+
+	tasks    []webhookrepo.Task
+	saved    []models.WebhookDelivery
+	released []int64
+}
+
+func (r *repoMock) ClaimDeliveries(context.Context, int, time.Duration) ([]webhookrepo.Task, error) {
+	tasks := r.tasks
+	r.tasks = nil
+
+	return tasks, nil
+}
+
+func (r *repoMock) SaveDelivery(_ context.Context, d models.WebhookDelivery) error {
+	r.saved = append(r.saved, d)
+
+	return nil
+}
+
+func (r *repoMock) ReleaseDelivery(ctx context.Context, id int64) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	r.released = append(r.released, id)
+
+	return nil
+}
+
+func TestDeliverPending(t *testing.T) {
+	payload := []byte(`{"event":"created"}`)
+	codes := []int{http.StatusInternalServerError, http.StatusNoContent, http.StatusBadGateway}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, payload, body)
+		require.Equal(t, "created", r.Header.Get(webhookservice.HeaderEvent))
+
+		ts, err := strconv.ParseInt(r.Header.Get(webhookservice.HeaderTimestamp), 10, 64)
+		require.NoError(t, err)
+		require.Equal(t, webhookservice.Sign("secret", ts, body), r.Header.Get(webhookservice.HeaderSignature))
+
+		w.WriteHeader(codes[0])
+		codes = codes[1:]
+	}))
+	defer srv.Close()
+
+	repo := &repoMock{}                             //nolint:exhaustruct
+	ws := webhookservice.New(repo, config.Webhooks{ //nolint:exhaustruct
+		BatchSize:   10,
+		Concurrency: 1,
+		Timeout:     time.Second,
+		MaxAttempts: 3,
+		MinBackoff:  time.Minute,
+		MaxBackoff:  time.Hour,
+	})
+
+	task := func(attempts int) webhookrepo.Task {
+		return webhookrepo.Task{
+			Delivery: models.WebhookDelivery{ //nolint:exhaustruct
+				ID: 1, Event: "created", Payload: payload, Status: models.DeliveryPending, Attempts: attempts,
+			},
+			URL:    srv.URL,
+			Secret: "secret",
+		}
+	}
+
+	// Ошибка получателя откладывает доставку со второй попытки на 2 * MinBackoff.
+	repo.tasks = []webhookrepo.Task{task(2)}
+	n, err := ws.DeliverPending(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, models.DeliveryPending, repo.saved[0].Status)
+	require.Equal(t, http.StatusInternalServerError, repo.saved[0].ResponseCode)
+	require.WithinDuration(t, time.Now().Add(2*time.Minute), repo.saved[0].NextAttemptAt, 5*time.Second)
+
+	repo.tasks = []webhookrepo.Task{task(3)}
+	_, err = ws.DeliverPending(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, models.DeliveryDelivered, repo.saved[1].Status)
+	require.False(t, repo.saved[1].DeliveredAt.IsZero())
+
+	// Последняя попытка исчерпана.
+	repo.tasks = []webhookrepo.Task{task(3)}
+	_, err = ws.DeliverPending(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, models.DeliveryFailed, repo.saved[2].Status)
+	require.Equal(t, "unexpected status 502", repo.saved[2].LastError)
+}
+
+func TestDeliverPendingShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		// Остановка во время отправки.
+		cancel()
+	}))
+	defer srv.Close()
+
+	repo := &repoMock{ //nolint:exhaustruct
+		tasks: []webhookrepo.Task{{
+			Delivery: models.WebhookDelivery{ID: 7, Status: models.DeliveryPending, Attempts: 1}, //nolint:exhaustruct
+			URL:      srv.URL,
+			Secret:   "secret",
+		}},
+	}
+	ws := webhookservice.New(repo, config.Webhooks{BatchSize: 10, Concurrency: 1, Timeout: time.Second}) //nolint:exhaustruct
+
+	_, err := ws.DeliverPending(ctx)
+	require.NoError(t, err)
+	require.Empty(t, repo.saved)
+	require.Equal(t, []int64{7}, repo.released)
+}
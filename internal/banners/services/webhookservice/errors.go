@@ -0,0 +1,8 @@
+package webhookservice
+
+import "errors"
+
+var (
+	ErrNotFound       = errors.New("webhook not found")
+	ErrInvalidWebhook = errors.New("invalid webhook")
+)
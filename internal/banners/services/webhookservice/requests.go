@@ -0,0 +1,16 @@
+package webhookservice
+
+type CreateWebhookRequest struct {
+	URL        string
+	Events     []string
+	FeatureIDs []int
+	// Secret - ключ подписи запросов. Если не задан, генерируется.
+	Secret string
+}
+
+type GetDeliveriesRequest struct {
+	WebhookID int
+	Status    string
+	Limit     int
+	Offset    int
+}
@@ -0,0 +1,158 @@
+package webhookservice
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/repository/webhookrepo"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+)
+
+const secretSize = 32
+
+type WebhookService struct {
+	repo   Repository
+	cfg    config.Webhooks
+	client *http.Client
+}
+
+type Repository interface {
+	CreateWebhook(context.Context, models.Webhook) (models.Webhook, error)
+	GetWebhooks(context.Context) ([]models.Webhook, error)
+	GetWebhook(context.Context, int) (models.Webhook, error)
+	DeleteWebhook(context.Context, int) error
+	GetDeliveries(context.Context, webhookrepo.GetDeliveriesRequest) ([]models.WebhookDelivery, error)
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]webhookrepo.Task, error)
+	SaveDelivery(context.Context, models.WebhookDelivery) error
+	ReleaseDelivery(ctx context.Context, id int64) error
+	PurgeDeliveries(ctx context.Context, before time.Time) (int64, error)
+}
+
+func New(repo Repository, cfg config.Webhooks) *WebhookService {
+	return &WebhookService{
+		repo: repo,
+		cfg:  cfg,
+		client: &http.Client{ //nolint:exhaustruct
+			Timeout: cfg.Timeout,
+			// Перенаправление считается неудачной доставкой: POST не должен
+			// превращаться в GET, а адрес вебхука - меняться без ведома админа.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// CreateWebhook регистрирует вебхук. Секрет возвращается только здесь.
+func (ws *WebhookService) CreateWebhook(ctx context.Context, req CreateWebhookRequest) (models.Webhook, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return models.Webhook{}, fmt.Errorf("%w: url must be absolute http(s) url", ErrInvalidWebhook)
+	}
+
+	if len(req.Events) == 0 {
+		return models.Webhook{}, fmt.Errorf("%w: no events", ErrInvalidWebhook)
+	}
+
+	events := make([]string, 0, len(req.Events))
+
+	for _, e := range req.Events {
+		switch e {
+		case models.EventCreated, models.EventUpdated, models.EventDeleted,
+			models.EventActivated:
+		default:
+			return models.Webhook{}, fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, e)
+		}
+
+		if !slices.Contains(events, e) {
+			events = append(events, e)
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		b := make([]byte, secretSize)
+		if _, err := rand.Read(b); err != nil {
+			return models.Webhook{}, fmt.Errorf("generate secret error: %w", err)
+		}
+
+		secret = hex.EncodeToString(b)
+	}
+
+	features := req.FeatureIDs
+	if features == nil {
+		features = []int{}
+	}
+
+	w, err := ws.repo.CreateWebhook(ctx, models.Webhook{ //nolint:exhaustruct
+		URL:        req.URL,
+		Secret:     secret,
+		Events:     events,
+		FeatureIDs: features,
+	})
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("create webhook error: %w", err)
+	}
+
+	return w, nil
+}
+
+func (ws *WebhookService) GetWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	webhooks, err := ws.repo.GetWebhooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get webhooks error: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (ws *WebhookService) DeleteWebhook(ctx context.Context, id int) error {
+	if err := ws.repo.DeleteWebhook(ctx, id); err != nil {
+		if errors.Is(err, webhookrepo.ErrNotFound) {
+			return ErrNotFound
+		}
+
+		return fmt.Errorf("delete webhook error: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeliveries возвращает журнал доставок вебхука, новые первыми.
+func (ws *WebhookService) GetDeliveries(ctx context.Context,
+	req GetDeliveriesRequest,
+) ([]models.WebhookDelivery, error) {
+	switch req.Status {
+	case "", models.DeliveryPending, models.DeliveryDelivered, models.DeliveryFailed:
+	default:
+		return nil, fmt.Errorf("%w: unknown delivery status %q", ErrInvalidWebhook, req.Status)
+	}
+
+	if _, err := ws.repo.GetWebhook(ctx, req.WebhookID); err != nil {
+		if errors.Is(err, webhookrepo.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf("get webhook error: %w", err)
+	}
+
+	deliveries, err := ws.repo.GetDeliveries(ctx, webhookrepo.GetDeliveriesRequest{
+		WebhookID: req.WebhookID,
+		Status:    req.Status,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get deliveries error: %w", err)
+	}
+
+	return deliveries, nil
+}
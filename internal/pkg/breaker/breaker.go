@@ -0,0 +1,59 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/sony/gobreaker"
+)
+
+// ErrUnavailable возвращается, пока автомат разомкнут и запросы
+// к зависимости не выполняются.
+var ErrUnavailable = errors.New("dependency unavailable")
+
+type Breaker struct {
+	cb *gobreaker.CircuitBreaker
+}
+
+// New создает автомат, который размыкается после FailureThreshold ошибок подряд
+// и через OpenTimeout пропускает пробный запрос. Ошибки, для которых ignore
+// возвращает true, не считаются отказом зависимости.
+func New(name string, cfg config.Breaker, lg logger.Logger, ignore func(error) bool) Breaker {
+	return Breaker{
+		cb: gobreaker.NewCircuitBreaker(gobreaker.Settings{ //nolint:exhaustruct
+			Name:    name,
+			Timeout: cfg.OpenTimeout,
+			ReadyToTrip: func(counts gobreaker.Counts) bool {
+				return counts.ConsecutiveFailures >= cfg.FailureThreshold
+			},
+			IsSuccessful: func(err error) bool {
+				return err == nil || errors.Is(err, context.Canceled) || ignore(err)
+			},
+			OnStateChange: func(name string, from, to gobreaker.State) {
+				lg.Warnf("circuit breaker %s state changed from %s to %s", name, from, to)
+			},
+		}),
+	}
+}
+
+func (b Breaker) State() string {
+	return b.cb.State().String()
+}
+
+func Do[T any](b Breaker, fn func() (T, error)) (T, error) {
+	res, err := b.cb.Execute(func() (interface{}, error) {
+		return fn()
+	})
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		var zero T
+
+		return zero, fmt.Errorf("%s: %w", b.cb.Name(), ErrUnavailable)
+	}
+
+	v, _ := res.(T) //nolint:errcheck
+
+	return v, err //nolint:wrapcheck
+}
@@ -0,0 +1,56 @@
+package breaker_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/breaker"
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errFailure = errors.New("failure")
+	errIgnored = errors.New("ignored")
+)
+
+func TestBreaker(t *testing.T) {
+	lg, err := logger.New(config.Logger{Level: "info"})
+	require.NoError(t, err)
+
+	b := breaker.New("test", config.Breaker{
+		Enabled:          true,
+		FailureThreshold: 2,
+		OpenTimeout:      50 * time.Millisecond,
+	}, lg, func(err error) bool { return errors.Is(err, errIgnored) })
+
+	fail := func() (int, error) { return 0, errFailure }
+	ok := func() (int, error) { return 1, nil }
+
+	// Игнорируемые ошибки возвращаются как есть и не размыкают автомат.
+	for i := 0; i < 3; i++ {
+		_, err = breaker.Do(b, func() (int, error) { return 0, errIgnored })
+		require.ErrorIs(t, err, errIgnored)
+	}
+
+	require.Equal(t, "closed", b.State())
+
+	for i := 0; i < 2; i++ {
+		_, err = breaker.Do(b, fail)
+		require.ErrorIs(t, err, errFailure)
+	}
+
+	require.Equal(t, "open", b.State())
+
+	_, err = breaker.Do(b, ok)
+	require.ErrorIs(t, err, breaker.ErrUnavailable)
+
+	time.Sleep(60 * time.Millisecond)
+
+	v, err := breaker.Do(b, ok)
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+	require.Equal(t, "closed", b.State())
+}
@@ -13,6 +13,16 @@ type Config struct {
 	PostgresDB PostgresDB `yaml:"db"`
 	Auth       Auth       `yaml:"auth"`
 	RedisCache RedisCache `yaml:"rdb"`
+	Leader     Leader     `yaml:"leader"`
+	Breaker    Breaker    `yaml:"breaker"`
+	Metrics    Metrics    `yaml:"metrics"`
+	Tracing    Tracing    `yaml:"tracing"`
+	RateLimit  RateLimit  `yaml:"rateLimit"`
+	GRPC       GRPC       `yaml:"grpc"`
+	Changes    Changes    `yaml:"changes"`
+	Webhooks   Webhooks   `yaml:"webhooks"`
+	Events     Events     `yaml:"events"`
+	Stats      Stats      `yaml:"stats"`
 }
 
 type Server struct {
@@ -20,12 +30,135 @@ type Server struct {
 	ReadTimeout  time.Duration `yaml:"readTimeout"`
 	IdleTimeout  time.Duration `yaml:"idleTimeout"`
 	WriteTimeout time.Duration `yaml:"writeTimeout"`
+	// DrainDelay - время между переводом /readyz в отказ и закрытием соединений.
+	DrainDelay time.Duration `yaml:"drainDelay"`
+	Stream     Stream        `yaml:"stream"`
+}
+
+// Stream ограничивает потоки изменений баннеров (GET /user_banner/stream).
+type Stream struct {
+	MaxConnections int `env-default:"10000" yaml:"maxConnections"`
+	MaxPerUser     int `env-default:"10"    yaml:"maxPerUser"`
+	// Heartbeat - период комментариев-пингов, по которым прокси и клиенты
+	// отличают тихий поток от оборванного.
+	Heartbeat time.Duration `env-default:"15s" yaml:"heartbeat"`
+	// MaxDuration закрывает поток, чтобы клиент переподключился, возможно,
+	// к другой реплике. 0 - без ограничения.
+	MaxDuration time.Duration `env-default:"30m" yaml:"maxDuration"`
+	// Retry - пауза перед переподключением, которую сервер сообщает клиенту.
+	Retry time.Duration `env-default:"3s" yaml:"retry"`
+}
+
+// Changes - шина изменений баннеров, общая для всех реплик: события
+// записываются в поток Redis, из которого читают WatchBanners
+// и GET /user_banner/stream.
+type Changes struct {
+	// Buffer - сколько событий копится для медленного подписчика, прежде
+	// чем он будет отключен.
+	Buffer int `env-default:"256" yaml:"buffer"`
+	// History - примерная длина потока Redis: более старые события удаляются
+	// и недоступны для продолжения потока по Last-Event-ID.
+	History int `env-default:"1024" yaml:"history"`
+}
+
+// Webhooks - отправка исходящих вебхуков. Enabled включает на реплике отправку
+// и управление вебхуками, события для зарегистрированных вебхуков записываются
+// всегда. Реплики делят доставки между собой через БД.
+type Webhooks struct {
+	Enabled      bool          `yaml:"enabled"`
+	PollInterval time.Duration `env-default:"1s"   yaml:"pollInterval"`
+	BatchSize    int           `env-default:"100"  yaml:"batchSize"`
+	Concurrency  int           `env-default:"10"   yaml:"concurrency"`
+	Timeout      time.Duration `env-default:"10s"  yaml:"timeout"`
+	// MaxAttempts - число попыток, после которого доставка считается неудачной.
+	MaxAttempts int `env-default:"10" yaml:"maxAttempts"`
+	// Пауза перед повтором удваивается с каждой попыткой от MinBackoff до MaxBackoff.
+	MinBackoff time.Duration `env-default:"10s" yaml:"minBackoff"`
+	MaxBackoff time.Duration `env-default:"1h"  yaml:"maxBackoff"`
+	// Retention - сколько хранятся завершенные доставки в журнале.
+	Retention time.Duration `env-default:"168h" yaml:"retention"`
+}
+
+// Events - публикация изменений баннеров из таблицы banner_events. События
+// записываются всегда, Enabled включает публикацию на реплике. Публикует одна
+// реплика за раз, остальные ждут.
+type Events struct {
+	Enabled bool `yaml:"enabled"`
+	// Publisher - куда публиковать: stdout, file, redis (Redis Streams) или nats (JetStream).
+	Publisher    string        `env-default:"stdout" yaml:"publisher"`
+	PollInterval time.Duration `env-default:"1s"     yaml:"pollInterval"`
+	BatchSize    int           `env-default:"100"    yaml:"batchSize"`
+	// Retention - сколько хранятся события, в том числе неопубликованные.
+	// Очистка идет на каждой реплике независимо от Enabled, 0 - без очистки.
+	Retention time.Duration `env-default:"168h" yaml:"retention"`
+	File      string        `yaml:"file"`
+	Redis     EventsRedis   `yaml:"redis"`
+	NATS      EventsNATS    `yaml:"nats"`
+}
+
+// EventsRedis - поток Redis Streams. Подключение берется из rdb.
+type EventsRedis struct {
+	Stream string `env-default:"banners:events" yaml:"stream"`
+	// MaxLen приблизительно ограничивает длину потока, 0 - без ограничения.
+	MaxLen int64 `yaml:"maxLen"`
+}
+
+type EventsNATS struct {
+	URL string `env-default:"nats://127.0.0.1:4222" yaml:"url"`
+	// Stream создается при запуске, если его нет, и хранит темы <Subject>.>.
+	Stream string `env-default:"BANNER_EVENTS" yaml:"stream"`
+	// Subject - префикс темы, событие публикуется в <Subject>.<event>.
+	Subject string        `env-default:"banners.events" yaml:"subject"`
+	Timeout time.Duration `env-default:"5s"             yaml:"timeout"`
+}
+
+// Stats - учет показов и кликов баннеров. Счетчики копятся в памяти реплики,
+// каждые PushInterval добавляются в общие счетчики в rdb, а каждые
+// FlushInterval переносятся оттуда в banner_stats. Без Enabled реплика не
+// считает показы, а /events/click и /banner/{id}/stats отвечают 404.
+type Stats struct {
+	Enabled       bool          `yaml:"enabled"`
+	PushInterval  time.Duration `env-default:"1s" yaml:"pushInterval"`
+	FlushInterval time.Duration `env-default:"1m" yaml:"flushInterval"`
+}
+
+// GRPC - сервер gRPC API, работающий рядом с REST API на отдельном порту.
+type GRPC struct {
+	Enabled bool   `yaml:"enabled"`
+	Addr    string `env-default:"0.0.0.0:5556" yaml:"addr"`
+	// MaxConnectionAge заставляет клиентов периодически переподключаться,
+	// чтобы нагрузка распределялась по новым репликам. 0 - без ограничения.
+	MaxConnectionAge time.Duration `yaml:"maxConnectionAge"`
 }
 
 type Logger struct {
-	Level     string   `yaml:"level"`
-	Output    []string `yaml:"output"`
-	ErrOutput []string `yaml:"errOutput"`
+	Level     string         `yaml:"level"`
+	Output    []string       `yaml:"output"`
+	ErrOutput []string       `yaml:"errOutput"`
+	Sampling  LoggerSampling `yaml:"sampling"`
+	Rotation  LoggerRotation `yaml:"rotation"`
+	// FileMode - права файлов логов в восьмеричной записи.
+	FileMode string `env-default:"0644" yaml:"fileMode"`
+}
+
+// LoggerRotation задает ротацию файлов логов. Файл ротируется, когда превышает
+// MaxSize мегабайт или с момента прошлой ротации прошло Interval (0 - только по размеру).
+// Архивы старше MaxAge или сверх MaxBackups удаляются.
+type LoggerRotation struct {
+	MaxSize    int           `env-default:"100"  yaml:"maxSize"`
+	MaxAge     time.Duration `env-default:"168h" yaml:"maxAge"`
+	MaxBackups int           `env-default:"10"   yaml:"maxBackups"`
+	Compress   bool          `env-default:"true" yaml:"compress"`
+	Interval   time.Duration `yaml:"interval"`
+}
+
+// LoggerSampling ограничивает число одинаковых записей: за каждый Tick
+// пишутся первые Initial записей с одним сообщением и уровнем, затем каждая Thereafter-я.
+type LoggerSampling struct {
+	Enabled    bool          `yaml:"enabled"`
+	Initial    int           `env-default:"100" yaml:"initial"`
+	Thereafter int           `env-default:"100" yaml:"thereafter"`
+	Tick       time.Duration `env-default:"1s"  yaml:"tick"`
 }
 
 type PostgresDB struct {
@@ -45,10 +178,56 @@ type Auth struct {
 }
 
 type RedisCache struct {
-	Addr     string        `yaml:"addr"`
-	Password string        `yaml:"password"`
-	DB       int           `yaml:"db"`
-	ExpTime  time.Duration `yaml:"exp"`
+	// Mode - режим подключения: standalone, failover (Sentinel) или cluster.
+	Mode string `env-default:"standalone" yaml:"mode"`
+	Addr string `yaml:"addr"`
+	// Addrs - адреса узлов Sentinel или кластера. Для standalone достаточно Addr.
+	Addrs            []string `yaml:"addrs"`
+	MasterName       string   `yaml:"masterName"`
+	SentinelPassword string   `yaml:"sentinelPassword"`
+	Password         string   `yaml:"password"`
+	DB               int      `yaml:"db"`
+	// ExpTime - жесткий TTL: после него баннер читается из БД.
+	ExpTime time.Duration `yaml:"exp"`
+	// SoftExpTime - мягкий TTL: после него баннер отдается из кэша, но обновляется в фоне.
+	// Нулевое значение отключает фоновое обновление.
+	SoftExpTime time.Duration `yaml:"softExp"`
+	// StaleIfError - сколько баннер хранится в кэше после ExpTime, чтобы
+	// отдавать пользователям последнюю известную версию, пока БД недоступна.
+	StaleIfError time.Duration `env-default:"1h" yaml:"staleIfError"`
+}
+
+type Leader struct {
+	Enabled       bool          `yaml:"enabled"`
+	NodeID        string        `env:"NODE_ID"                yaml:"nodeID"`
+	Key           string        `env-default:"banners:leader" yaml:"key"`
+	LeaseTTL      time.Duration `env-default:"15s"            yaml:"leaseTTL"`
+	RenewInterval time.Duration `env-default:"5s"             yaml:"renewInterval"`
+}
+
+type Breaker struct {
+	Enabled          bool          `yaml:"enabled"`
+	FailureThreshold uint32        `env-default:"5"   yaml:"failureThreshold"`
+	OpenTimeout      time.Duration `env-default:"10s" yaml:"openTimeout"`
+}
+
+type Metrics struct {
+	Enabled      bool          `yaml:"enabled"`
+	Addr         string        `env-default:"0.0.0.0:9090" yaml:"addr"`
+	Path         string        `env-default:"/metrics"     yaml:"path"`
+	ReadTimeout  time.Duration `env-default:"5s"           yaml:"readTimeout"`
+	WriteTimeout time.Duration `env-default:"10s"          yaml:"writeTimeout"`
+}
+
+type Tracing struct {
+	// Exporter - куда отправлять спаны: none, otlp, stdout или file.
+	Exporter string `env-default:"none" yaml:"exporter"`
+	// Endpoint - адрес OTLP/HTTP коллектора, например otel-collector:4318.
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	File        string  `yaml:"file"`
+	ServiceName string  `env-default:"banners" yaml:"serviceName"`
+	SampleRatio float64 `env-default:"1"       yaml:"sampleRatio"`
 }
 
 func New(configPath string) (Config, error) {
@@ -59,3 +238,21 @@ func New(configPath string) (Config, error) {
 
 	return cfg, nil
 }
+
+// RateLimit задает ограничения частоты запросов по классам маршрутов.
+// Store = memory хранит счетчики в памяти узла, redis - общие для всех реплик.
+type RateLimit struct {
+	Enabled    bool          `yaml:"enabled"`
+	Store      string        `env-default:"memory" yaml:"store"`
+	User       RateLimitRule `yaml:"user"`
+	AdminRead  RateLimitRule `yaml:"adminRead"`
+	AdminWrite RateLimitRule `yaml:"adminWrite"`
+	Default    RateLimitRule `yaml:"default"`
+}
+
+// RateLimitRule - корзина токенов: Rate токенов в секунду, не больше Burst.
+// Нулевой Rate снимает ограничение.
+type RateLimitRule struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
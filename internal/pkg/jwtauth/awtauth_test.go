@@ -29,6 +29,10 @@ func TestBasic(t *testing.T) {
 	role, err := jwtauth.ValidateTokenRole(token, secret)
 	require.NoError(t, err)
 	require.Equal(t, userExample.Role, role)
+
+	claims, err := jwtauth.ParseToken(token, secret)
+	require.NoError(t, err)
+	require.Equal(t, userExample.Username, claims.Subject)
 }
 
 func TestValidateToken(t *testing.T) {
@@ -28,6 +28,7 @@ func GetToken(user models.User, ttl time.Duration, secret string) (string, error
 	}
 
 	claims["role"] = user.Role
+	claims["sub"] = user.Username
 	claims["exp"] = time.Now().Add(ttl).Unix()
 
 	t, err := token.SignedString([]byte(secret))
@@ -38,7 +39,23 @@ func GetToken(user models.User, ttl time.Duration, secret string) (string, error
 	return t, nil
 }
 
+type Claims struct {
+	Role    string
+	Subject string
+}
+
 func ValidateTokenRole(tokenString string, secret string) (string, error) {
+	claims, err := ParseToken(tokenString, secret)
+	if err != nil {
+		return "", err
+	}
+
+	return claims.Role, nil
+}
+
+// ParseToken проверяет токен и возвращает его утверждения. В токенах,
+// выданных до появления утверждения sub, Subject пустой.
+func ParseToken(tokenString string, secret string) (Claims, error) {
 	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
@@ -50,30 +67,32 @@ func ValidateTokenRole(tokenString string, secret string) (string, error) {
 		jwtErr := new(jwt.ValidationError)
 		if errors.As(err, &jwtErr) {
 			if jwtErr.Errors == jwt.ValidationErrorExpired {
-				return "", ErrTokenExpired
+				return Claims{}, ErrTokenExpired
 			}
 		}
 
-		return "", fmt.Errorf("parse token error: %w", err)
+		return Claims{}, fmt.Errorf("parse token error: %w", err)
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		role, ok := claims["role"].(string)
 		if !ok {
-			return "", ErrNoClaim
+			return Claims{}, ErrNoClaim
 		}
 
 		exp, ok := claims["exp"].(float64)
 		if !ok {
-			return "", ErrNoClaim
+			return Claims{}, ErrNoClaim
 		}
 
 		if int64(exp) < time.Now().Unix() {
-			return "", ErrTokenExpired
+			return Claims{}, ErrTokenExpired
 		}
 
-		return role, nil
+		sub, _ := claims["sub"].(string)
+
+		return Claims{Role: role, Subject: sub}, nil
 	}
 
-	return "", ErrInvalidToken
+	return Claims{}, ErrInvalidToken
 }
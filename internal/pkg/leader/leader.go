@@ -0,0 +1,210 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/internal/pkg/redistools"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// Продление и освобождение аренды выполняются атомарно: ключ меняет
+// только тот узел, чей идентификатор в нём записан.
+var (
+	renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+	releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// Elector выбирает лидера среди реплик сервиса с помощью аренды ключа в Redis.
+// Если выборы отключены в конфигурации, узел всегда считает себя лидером.
+type Elector struct {
+	rdb redis.UniversalClient
+	cfg config.Leader
+	id  string
+	lg  logger.Logger
+
+	// acquired получает сигнал, когда узел становится лидером.
+	acquired chan struct{}
+
+	mu       sync.RWMutex
+	isLeader bool
+	leader   string
+}
+
+func New(ctx context.Context, cfg config.Leader, rdbCfg config.RedisCache, lg logger.Logger) (*Elector, error) {
+	id := cfg.NodeID
+	if id == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("get hostname error: %w", err)
+		}
+
+		id = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	e := &Elector{ //nolint:exhaustruct
+		cfg:      cfg,
+		id:       id,
+		lg:       lg,
+		acquired: make(chan struct{}, 1),
+	}
+
+	if !cfg.Enabled {
+		e.isLeader = true
+		e.leader = id
+
+		return e, nil
+	}
+
+	rdb, err := redistools.NewClient(rdbCfg)
+	if err != nil {
+		return nil, fmt.Errorf("create redis client error: %w", err)
+	}
+
+	e.rdb = rdb
+
+	// Первая попытка делается сразу, чтобы лидер прогрел кэш при старте.
+	// Если Redis недоступен, узел остается ведомым до следующей попытки в Run.
+	if err := e.campaign(ctx); err != nil {
+		lg.Errorf("leader election error: %s", err.Error())
+	}
+
+	return e, nil
+}
+
+// Run участвует в выборах до отмены контекста. При завершении
+// аренда освобождается, чтобы другая реплика сразу могла её захватить.
+func (e *Elector) Run(ctx context.Context) {
+	if !e.cfg.Enabled {
+		return
+	}
+
+	t := time.NewTicker(e.cfg.RenewInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.release() //nolint:contextcheck
+
+			return
+		case <-t.C:
+			if err := e.campaign(ctx); err != nil {
+				e.lg.Errorf("leader election error: %s", err.Error())
+			}
+		}
+	}
+}
+
+func (e *Elector) ID() string {
+	return e.id
+}
+
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.isLeader
+}
+
+// Acquired возвращает канал, в который приходит сигнал, когда узел становится
+// лидером. Сигналы не копятся: непрочитанный сигнал поглощает следующие.
+// Если выборы отключены, сигналов нет - узел лидер с самого начала.
+func (e *Elector) Acquired() <-chan struct{} {
+	return e.acquired
+}
+
+// Leader возвращает идентификатор текущего лидера, известный этому узлу.
+func (e *Elector) Leader() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.leader
+}
+
+func (e *Elector) campaign(ctx context.Context) error {
+	ttl := e.cfg.LeaseTTL.Milliseconds()
+
+	if e.IsLeader() {
+		renewed, err := renewScript.Run(ctx, e.rdb, []string{e.cfg.Key}, e.id, ttl).Int()
+		if err != nil {
+			// Не удалось подтвердить аренду: считаем, что лидерство потеряно,
+			// иначе два узла могут одновременно выполнять фоновую работу.
+			e.set(false, "")
+
+			return fmt.Errorf("renew lease error: %w", err)
+		}
+
+		if renewed == 1 {
+			e.set(true, e.id)
+
+			return nil
+		}
+	}
+
+	acquired, err := e.rdb.SetNX(ctx, e.cfg.Key, e.id, e.cfg.LeaseTTL).Result()
+	if err != nil {
+		e.set(false, "")
+
+		return fmt.Errorf("acquire lease error: %w", err)
+	}
+
+	if acquired {
+		e.set(true, e.id)
+
+		return nil
+	}
+
+	current, err := e.rdb.Get(ctx, e.cfg.Key).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		e.set(false, "")
+
+		return fmt.Errorf("get leader error: %w", err)
+	}
+
+	e.set(current == e.id, current)
+
+	return nil
+}
+
+func (e *Elector) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.RenewInterval)
+	defer cancel()
+
+	if e.IsLeader() {
+		releaseScript.Run(ctx, e.rdb, []string{e.cfg.Key}, e.id) //nolint:errcheck
+	}
+
+	e.set(false, "")
+
+	e.rdb.Close() //nolint:errcheck
+}
+
+func (e *Elector) set(isLeader bool, leader string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if isLeader && !e.isLeader {
+		select {
+		case e.acquired <- struct{}{}:
+		default:
+		}
+	}
+
+	e.isLeader = isLeader
+	e.leader = leader
+}
@@ -0,0 +1,64 @@
+package leader_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/internal/pkg/leader"
+	"github.com/Leopold1975/banners_control/pkg/logger"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquired(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	lg, err := logger.New(config.Logger{Level: "info"}) //nolint:exhaustruct
+	require.NoError(t, err)
+
+	cfg := config.Leader{ //nolint:exhaustruct
+		Enabled:       true,
+		Key:           "banners:leader",
+		LeaseTTL:      time.Second,
+		RenewInterval: 10 * time.Millisecond,
+	}
+	rdbCfg := config.RedisCache{Addr: mr.Addr()} //nolint:exhaustruct
+
+	cfg.NodeID = "first"
+	first, err := leader.New(context.Background(), cfg, rdbCfg, lg)
+	require.NoError(t, err)
+	require.True(t, first.IsLeader())
+
+	cfg.NodeID = "second"
+	second, err := leader.New(context.Background(), cfg, rdbCfg, lg)
+	require.NoError(t, err)
+	require.False(t, second.IsLeader())
+	require.Equal(t, "first", second.Leader())
+
+	ctxFirst, cancelFirst := context.WithCancel(context.Background())
+	ctxSecond, cancelSecond := context.WithCancel(context.Background())
+
+	defer cancelSecond()
+
+	go first.Run(ctxFirst)
+	go second.Run(ctxSecond)
+
+	select {
+	case <-second.Acquired():
+		t.Fatal("unexpected leadership")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Лидер освобождает аренду при остановке, и ведомый сразу получает сигнал.
+	cancelFirst()
+
+	select {
+	case <-second.Acquired():
+	case <-time.After(time.Second / 2):
+		t.Fatal("leadership not acquired before lease expiry")
+	}
+
+	require.True(t, second.IsLeader())
+}
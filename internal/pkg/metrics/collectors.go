@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	pgxAcquiredConns = prometheus.NewDesc("pgxpool_acquired_conns",
+		"Number of currently acquired connections in the pool.", []string{"pool"}, nil)
+	pgxIdleConns = prometheus.NewDesc("pgxpool_idle_conns",
+		"Number of currently idle connections in the pool.", []string{"pool"}, nil)
+	pgxTotalConns = prometheus.NewDesc("pgxpool_total_conns",
+		"Total number of connections in the pool.", []string{"pool"}, nil)
+	pgxMaxConns = prometheus.NewDesc("pgxpool_max_conns",
+		"Maximum size of the pool.", []string{"pool"}, nil)
+	pgxAcquireCount = prometheus.NewDesc("pgxpool_acquire_total",
+		"Cumulative count of successful acquires from the pool.", []string{"pool"}, nil)
+	pgxAcquireDuration = prometheus.NewDesc("pgxpool_acquire_duration_seconds_total",
+		"Total time spent acquiring connections from the pool.", []string{"pool"}, nil)
+	pgxEmptyAcquireCount = prometheus.NewDesc("pgxpool_empty_acquire_total",
+		"Cumulative count of acquires that waited for a connection.", []string{"pool"}, nil)
+
+	redisHits = prometheus.NewDesc("redis_pool_hits_total",
+		"Number of times a free connection was found in the pool.", []string{"pool"}, nil)
+	redisMisses = prometheus.NewDesc("redis_pool_misses_total",
+		"Number of times a free connection was not found in the pool.", []string{"pool"}, nil)
+	redisTimeouts = prometheus.NewDesc("redis_pool_timeouts_total",
+		"Number of times a wait timeout occurred.", []string{"pool"}, nil)
+	redisTotalConns = prometheus.NewDesc("redis_pool_total_conns",
+		"Number of total connections in the pool.", []string{"pool"}, nil)
+	redisIdleConns = prometheus.NewDesc("redis_pool_idle_conns",
+		"Number of idle connections in the pool.", []string{"pool"}, nil)
+	redisStaleConns = prometheus.NewDesc("redis_pool_stale_conns_total",
+		"Number of stale connections removed from the pool.", []string{"pool"}, nil)
+)
+
+type pgxPoolCollector struct {
+	pool string
+	stat func() *pgxpool.Stat
+}
+
+func (c *pgxPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		pgxAcquiredConns, pgxIdleConns, pgxTotalConns, pgxMaxConns,
+		pgxAcquireCount, pgxAcquireDuration, pgxEmptyAcquireCount,
+	} {
+		ch <- d
+	}
+}
+
+func (c *pgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stat()
+
+	ch <- prometheus.MustNewConstMetric(pgxAcquiredConns, prometheus.GaugeValue, float64(s.AcquiredConns()), c.pool)
+	ch <- prometheus.MustNewConstMetric(pgxIdleConns, prometheus.GaugeValue, float64(s.IdleConns()), c.pool)
+	ch <- prometheus.MustNewConstMetric(pgxTotalConns, prometheus.GaugeValue, float64(s.TotalConns()), c.pool)
+	ch <- prometheus.MustNewConstMetric(pgxMaxConns, prometheus.GaugeValue, float64(s.MaxConns()), c.pool)
+	ch <- prometheus.MustNewConstMetric(pgxAcquireCount, prometheus.CounterValue, float64(s.AcquireCount()), c.pool)
+	ch <- prometheus.MustNewConstMetric(pgxAcquireDuration, prometheus.CounterValue,
+		s.AcquireDuration().Seconds(), c.pool)
+	ch <- prometheus.MustNewConstMetric(pgxEmptyAcquireCount, prometheus.CounterValue,
+		float64(s.EmptyAcquireCount()), c.pool)
+}
+
+type redisPoolCollector struct {
+	pool string
+	stat func() *redis.PoolStats
+}
+
+func (c *redisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		redisHits, redisMisses, redisTimeouts, redisTotalConns, redisIdleConns, redisStaleConns,
+	} {
+		ch <- d
+	}
+}
+
+func (c *redisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stat()
+
+	ch <- prometheus.MustNewConstMetric(redisHits, prometheus.CounterValue, float64(s.Hits), c.pool)
+	ch <- prometheus.MustNewConstMetric(redisMisses, prometheus.CounterValue, float64(s.Misses), c.pool)
+	ch <- prometheus.MustNewConstMetric(redisTimeouts, prometheus.CounterValue, float64(s.Timeouts), c.pool)
+	ch <- prometheus.MustNewConstMetric(redisTotalConns, prometheus.GaugeValue, float64(s.TotalConns), c.pool)
+	ch <- prometheus.MustNewConstMetric(redisIdleConns, prometheus.GaugeValue, float64(s.IdleConns), c.pool)
+	ch <- prometheus.MustNewConstMetric(redisStaleConns, prometheus.CounterValue, float64(s.StaleConns), c.pool)
+}
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/redis/go-redis/v9"
+)
+
+const namespace = "banners"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	cache        *prometheus.CounterVec
+
+	refreshDuration    prometheus.Histogram
+	refreshFailures    prometheus.Counter
+	refreshLastSuccess prometheus.Gauge
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{ //nolint:exhaustruct
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests by route, method and status code.",
+		}, []string{"method", "route", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{ //nolint:exhaustruct
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by route and method.",
+			// Сетка сгущается около SLI в 50 мс.
+			Buckets: []float64{.001, .0025, .005, .01, .025, .05, .075, .1, .25, .5, 1, 2.5},
+		}, []string{"method", "route"}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{ //nolint:exhaustruct
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "requests_total",
+			Help:      "Number of user banner cache lookups by result (hit or miss).",
+		}, []string{"result"}),
+		refreshDuration: prometheus.NewHistogram(prometheus.HistogramOpts{ //nolint:exhaustruct
+			Namespace: namespace,
+			Subsystem: "refresh",
+			Name:      "duration_seconds",
+			Help:      "Duration of background cache refresh.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12), //nolint:gomnd
+		}),
+		refreshFailures: prometheus.NewCounter(prometheus.CounterOpts{ //nolint:exhaustruct
+			Namespace: namespace,
+			Subsystem: "refresh",
+			Name:      "failures_total",
+			Help:      "Number of failed background cache refreshes.",
+		}),
+		refreshLastSuccess: prometheus.NewGauge(prometheus.GaugeOpts{ //nolint:exhaustruct
+			Namespace: namespace,
+			Subsystem: "refresh",
+			Name:      "last_success_timestamp_seconds",
+			Help:      "Unix time of the last successful background cache refresh.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}), //nolint:exhaustruct
+		m.httpRequests,
+		m.httpDuration,
+		m.cache,
+		m.refreshDuration,
+		m.refreshFailures,
+		m.refreshLastSuccess,
+	)
+
+	return m
+}
+
+func (m *Metrics) ObserveRequest(method, route string, code int, duration time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(code)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+func (m *Metrics) CacheHit() {
+	m.cache.WithLabelValues("hit").Inc()
+}
+
+func (m *Metrics) CacheMiss() {
+	m.cache.WithLabelValues("miss").Inc()
+}
+
+func (m *Metrics) ObserveRefresh(duration time.Duration, err error) {
+	m.refreshDuration.Observe(duration.Seconds())
+
+	if err != nil {
+		m.refreshFailures.Inc()
+
+		return
+	}
+
+	m.refreshLastSuccess.SetToCurrentTime()
+}
+
+// RegisterPgxPool экспортирует статистику пула соединений с БД.
+// Значение метки pool различает пулы разных репозиториев.
+func (m *Metrics) RegisterPgxPool(pool string, stat func() *pgxpool.Stat) {
+	m.registry.MustRegister(&pgxPoolCollector{pool: pool, stat: stat})
+}
+
+// RegisterRedisPool экспортирует статистику пула соединений с Redis.
+func (m *Metrics) RegisterRedisPool(pool string, stat func() *redis.PoolStats) {
+	m.registry.MustRegister(&redisPoolCollector{pool: pool, stat: stat})
+}
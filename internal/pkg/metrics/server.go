@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server отдает метрики на отдельном адресе, чтобы они не были доступны
+// через публичный API.
+type Server struct {
+	serv *http.Server
+}
+
+func NewServer(cfg config.Metrics, m *Metrics) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(cfg.Path, promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{ //nolint:exhaustruct
+		Registry: m.registry,
+	}))
+
+	return &Server{
+		serv: &http.Server{ //nolint:exhaustruct
+			Addr:              cfg.Addr,
+			Handler:           mux,
+			ReadHeaderTimeout: cfg.ReadTimeout,
+			ReadTimeout:       cfg.ReadTimeout,
+			WriteTimeout:      cfg.WriteTimeout,
+		},
+	}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		if err := s.serv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+
+		close(errCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-errCh:
+		if err != nil {
+			return fmt.Errorf("listen and serve error: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func (s *Server) Shutdown(ctx context.Context) error {
+	if err := s.serv.Shutdown(ctx); err != nil {
+		return fmt.Errorf("shutdown metrics server error: %w", err)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/exaring/otelpgx"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib" // driver for migrations
@@ -19,9 +20,9 @@ func Connect(ctx context.Context, connString string) (*pgxpool.Pool, error) {
 	go func() {
 		defer close(errCh)
 
-		dbc, err := pgxpool.New(ctx, connString)
+		dbc, err := Open(ctx, connString)
 		if err != nil {
-			errCh <- fmt.Errorf("cannot create db pool error: %w", err)
+			errCh <- err
 
 			return
 		}
@@ -59,6 +60,24 @@ func Connect(ctx context.Context, connString string) (*pgxpool.Pool, error) {
 	}
 }
 
+// Open создает пул соединений без проверки доступности БД.
+// Запросы через пул попадают в трассировку.
+func Open(ctx context.Context, connString string) (*pgxpool.Pool, error) {
+	cfg, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, fmt.Errorf("parse db config error: %w", err)
+	}
+
+	cfg.ConnConfig.Tracer = otelpgx.NewTracer(otelpgx.WithTrimSQLInSpanName())
+
+	db, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create db pool error: %w", err)
+	}
+
+	return db, nil
+}
+
 func ApplyMigration(cfg config.PostgresDB) error {
 	migrationsDir := "./migrations"
 	defaultVersion := 0
@@ -90,6 +109,19 @@ func ApplyMigration(cfg config.PostgresDB) error {
 	return nil
 }
 
+// MigrationVersion возвращает версию последней примененной миграции из таблицы goose.
+func MigrationVersion(ctx context.Context, db *pgxpool.Pool) (int64, error) {
+	var version int64
+
+	err := db.QueryRow(ctx, `SELECT version_id FROM goose_db_version
+		WHERE is_applied ORDER BY id DESC LIMIT 1`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("query migration version error: %w", err)
+	}
+
+	return version, nil
+}
+
 func CommitOrRollback(ctx context.Context, tx pgx.Tx, err error, where string) error {
 	if err == nil {
 		if errT := tx.Commit(ctx); errT != nil {
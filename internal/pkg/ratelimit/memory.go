@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+)
+
+// sweepInterval - как часто из памяти удаляются полные корзины.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	rule   config.RateLimitRule
+}
+
+// MemoryLimiter хранит корзины в памяти узла. Подходит для запуска одной реплики.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemory() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+func (ml *MemoryLimiter) Allow(_ context.Context, class, k string, rule config.RateLimitRule) (Result, error) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	now := ml.now()
+	ml.sweep(now)
+
+	b, ok := ml.buckets[key(class, k)]
+	if !ok {
+		b = &bucket{tokens: float64(rule.Burst), last: now, rule: rule}
+		ml.buckets[key(class, k)] = b
+	}
+
+	tokens, allowed := refill(rule, b.tokens, now.Sub(b.last))
+	b.tokens, b.last, b.rule = tokens, now, rule
+
+	return result(rule, tokens, allowed), nil
+}
+
+// sweep удаляет корзины, которые успели наполниться: они неотличимы от новых.
+func (ml *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(ml.lastSweep) < sweepInterval {
+		return
+	}
+
+	ml.lastSweep = now
+
+	for k, b := range ml.buckets {
+		if tokens, _ := refill(b.rule, b.tokens, now.Sub(b.last)); tokens+1 >= float64(b.rule.Burst) {
+			delete(ml.buckets, k)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+)
+
+// Классы маршрутов с отдельными бюджетами запросов.
+const (
+	ClassUser       = "user"
+	ClassAdminRead  = "admin_read"
+	ClassAdminWrite = "admin_write"
+	ClassDefault    = "default"
+)
+
+// Policy выбирает правило по классу маршрута.
+type Policy struct {
+	l     Limiter
+	rules map[string]config.RateLimitRule
+}
+
+func NewPolicy(l Limiter, cfg config.RateLimit) *Policy {
+	return &Policy{
+		l: l,
+		rules: map[string]config.RateLimitRule{
+			ClassUser:       cfg.User,
+			ClassAdminRead:  cfg.AdminRead,
+			ClassAdminWrite: cfg.AdminWrite,
+			ClassDefault:    cfg.Default,
+		},
+	}
+}
+
+// Allow пропускает запрос без учета, если для класса ограничение не задано;
+// в этом случае Limit равен нулю.
+func (p *Policy) Allow(ctx context.Context, class, key string) (Result, error) {
+	rule, ok := p.rules[class]
+	if !ok {
+		rule = p.rules[ClassDefault]
+	}
+
+	if rule.Rate <= 0 || rule.Burst <= 0 {
+		return Result{Allowed: true}, nil //nolint:exhaustruct
+	}
+
+	return p.l.Allow(ctx, class, key, rule)
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+)
+
+const (
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+)
+
+var ErrUnknownStore = errors.New("unknown rate limit store")
+
+// Limiter расходует токен из корзины ключа. Корзины разных классов
+// маршрутов независимы, даже если ключ совпадает.
+type Limiter interface {
+	Allow(ctx context.Context, class, key string, rule config.RateLimitRule) (Result, error)
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset - время до полного наполнения корзины.
+	Reset time.Duration
+	// RetryAfter - время до появления следующего токена, если запрос отклонен.
+	RetryAfter time.Duration
+}
+
+// refill пополняет корзину за прошедшее время и пытается взять из нее токен.
+func refill(rule config.RateLimitRule, tokens float64, elapsed time.Duration) (float64, bool) {
+	tokens = math.Min(float64(rule.Burst), tokens+elapsed.Seconds()*rule.Rate)
+	if tokens < 1 {
+		return tokens, false
+	}
+
+	return tokens - 1, true
+}
+
+func result(rule config.RateLimitRule, tokens float64, allowed bool) Result {
+	r := Result{
+		Allowed:    allowed,
+		Limit:      rule.Burst,
+		Remaining:  int(tokens),
+		Reset:      seconds((float64(rule.Burst) - tokens) / rule.Rate),
+		RetryAfter: 0,
+	}
+
+	if !allowed {
+		r.RetryAfter = seconds((1 - tokens) / rule.Rate)
+	}
+
+	return r
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func key(class, key string) string {
+	return fmt.Sprintf("ratelimit:%s:%s", class, key)
+}
@@ -0,0 +1,53 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/Leopold1975/banners_control/internal/pkg/ratelimit"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiters(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()}) //nolint:exhaustruct
+
+	limiters := map[string]ratelimit.Limiter{
+		"memory": ratelimit.NewMemory(),
+		"redis":  ratelimit.NewRedis(rdb),
+	}
+
+	for name, l := range limiters {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			rule := config.RateLimitRule{Rate: 1, Burst: 2}
+
+			for i := 1; i >= 0; i-- {
+				res, err := l.Allow(ctx, "user", "alice", rule)
+				require.NoError(t, err)
+				require.True(t, res.Allowed)
+				require.Equal(t, 2, res.Limit)
+				require.Equal(t, i, res.Remaining)
+			}
+
+			res, err := l.Allow(ctx, "user", "alice", rule)
+			require.NoError(t, err)
+			require.False(t, res.Allowed)
+			require.Positive(t, res.RetryAfter)
+			require.LessOrEqual(t, res.RetryAfter, time.Second)
+
+			// Корзины других ключей и классов независимы.
+			res, err = l.Allow(ctx, "user", "bob", rule)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+
+			res, err = l.Allow(ctx, "admin_write", "alice", rule)
+			require.NoError(t, err)
+			require.True(t, res.Allowed)
+		})
+	}
+}
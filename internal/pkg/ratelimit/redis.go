@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// Корзина пополняется и расходуется атомарно. Время передает клиент,
+// поэтому часы реплик должны быть синхронизированы.
+var allowScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local b = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(b[1])
+local ts = tonumber(b[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+return {allowed, tostring(tokens)}`)
+
+// RedisLimiter хранит корзины в Redis, поэтому ограничение общее для всех реплик.
+type RedisLimiter struct {
+	rdb redis.UniversalClient
+}
+
+func NewRedis(rdb redis.UniversalClient) RedisLimiter {
+	return RedisLimiter{rdb: rdb}
+}
+
+func (rl RedisLimiter) Allow(ctx context.Context, class, k string, rule config.RateLimitRule) (Result, error) {
+	// Корзина хранится, пока не наполнится, затем неотличима от новой.
+	ttl := time.Duration(math.Ceil(float64(rule.Burst)/rule.Rate*1000))*time.Millisecond + time.Second
+
+	res, err := allowScript.Run(ctx, rl.rdb, []string{key(class, k)},
+		rule.Rate, rule.Burst, time.Now().UnixMilli(), ttl.Milliseconds()).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("run allow script error: %w", err)
+	}
+
+	allowed, _ := res[0].(int64)
+	tokensStr, _ := res[1].(string)
+
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("parse tokens error: %w", err)
+	}
+
+	return result(rule, tokens, allowed == 1), nil
+}
@@ -2,13 +2,70 @@ package redistools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
-func Connect(ctx context.Context, rdb *redis.Client) error {
+const (
+	ModeStandalone = "standalone"
+	ModeFailover   = "failover"
+	ModeCluster    = "cluster"
+)
+
+var ErrUnknownMode = errors.New("unknown redis mode")
+
+// NewClient создает клиента для одиночного Redis, Redis под управлением Sentinel
+// или Redis Cluster в зависимости от cfg.Mode.
+func NewClient(cfg config.RedisCache) (redis.UniversalClient, error) { //nolint:ireturn
+	rdb, err := newClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := redisotel.InstrumentTracing(rdb); err != nil {
+		return nil, fmt.Errorf("instrument tracing error: %w", err)
+	}
+
+	return rdb, nil
+}
+
+func newClient(cfg config.RedisCache) (redis.UniversalClient, error) { //nolint:ireturn
+	addrs := cfg.Addrs
+	if len(addrs) == 0 {
+		addrs = []string{cfg.Addr}
+	}
+
+	switch cfg.Mode {
+	case "", ModeStandalone:
+		return redis.NewClient(&redis.Options{ //nolint:exhaustruct
+			Addr:     addrs[0],
+			Password: cfg.Password,
+			DB:       cfg.DB,
+		}), nil
+	case ModeFailover:
+		return redis.NewFailoverClient(&redis.FailoverOptions{ //nolint:exhaustruct
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    addrs,
+			SentinelPassword: cfg.SentinelPassword,
+			Password:         cfg.Password,
+			DB:               cfg.DB,
+		}), nil
+	case ModeCluster:
+		return redis.NewClusterClient(&redis.ClusterOptions{ //nolint:exhaustruct
+			Addrs:    addrs,
+			Password: cfg.Password,
+		}), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMode, cfg.Mode)
+	}
+}
+
+func Connect(ctx context.Context, rdb redis.UniversalClient) error {
 	errCh := make(chan error)
 	go func() {
 		defer close(errCh)
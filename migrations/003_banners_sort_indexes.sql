@@ -0,0 +1,9 @@
+-- +goose up
+CREATE INDEX IF NOT EXISTS banners_created_at_id_idx ON banners (created_at, id);
+CREATE INDEX IF NOT EXISTS banners_updated_at_id_idx ON banners (updated_at, id);
+CREATE INDEX IF NOT EXISTS banners_feature_id_id_idx ON banners (feature_id, id);
+
+-- +goose down
+DROP INDEX IF EXISTS banners_feature_id_id_idx;
+DROP INDEX IF EXISTS banners_updated_at_id_idx;
+DROP INDEX IF EXISTS banners_created_at_id_idx;
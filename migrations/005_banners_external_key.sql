@@ -0,0 +1,9 @@
+-- +goose up
+ALTER TABLE banners ADD COLUMN IF NOT EXISTS external_key text;
+
+CREATE UNIQUE INDEX IF NOT EXISTS banners_external_key_idx ON banners (external_key);
+
+-- +goose down
+DROP INDEX IF EXISTS banners_external_key_idx;
+
+ALTER TABLE banners DROP COLUMN IF EXISTS external_key;
@@ -0,0 +1,16 @@
+-- +goose up
+-- Исходящие события об изменениях баннеров: строки пишутся в транзакции
+-- изменения и публикуются фоновым обработчиком.
+CREATE TABLE IF NOT EXISTS banner_events (
+    id BIGSERIAL primary key,
+    banner_id bigint not null,
+    event text not null,
+    payload jsonb not null,
+    created_at timestamptz not null DEFAULT current_timestamp,
+    published_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS banner_events_unpublished_idx ON banner_events (id) WHERE published_at IS NULL;
+
+-- +goose down
+DROP TABLE IF EXISTS banner_events;
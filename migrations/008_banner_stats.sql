@@ -0,0 +1,14 @@
+-- +goose up
+-- Показы и клики баннеров по тэгам пользователей с точностью до часа.
+-- Счетчики копятся в Redis и периодически добавляются сюда.
+CREATE TABLE IF NOT EXISTS banner_stats (
+    banner_id bigint not null,
+    tag_id int not null,
+    hour timestamptz not null,
+    impressions bigint not null DEFAULT 0,
+    clicks bigint not null DEFAULT 0,
+    primary key (banner_id, hour, tag_id)
+);
+
+-- +goose down
+DROP TABLE IF EXISTS banner_stats;
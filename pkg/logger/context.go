@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+type ctxKey struct{}
+
+// global хранит логгер, созданный последним вызовом New.
+var global atomic.Value
+
+// WithContext сохраняет логгер в контексте. Обычно это логгер запроса
+// с полями request_id, route и user.
+func WithContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext возвращает логгер из контекста, а если его там нет -
+// логгер, созданный последним вызовом New.
+func FromContext(ctx context.Context) Logger {
+	if l, ok := ctx.Value(ctxKey{}).(Logger); ok {
+		return l
+	}
+
+	if l, ok := global.Load().(Logger); ok {
+		return l
+	}
+
+	return Logger{SugaredLogger: zap.S(), level: zap.NewAtomicLevel()}
+}
+
+func (l Logger) With(args ...interface{}) Logger {
+	return Logger{SugaredLogger: l.SugaredLogger.With(args...), level: l.level}
+}
@@ -1,10 +1,9 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"os"
-	"path/filepath"
 
 	"github.com/Leopold1975/banners_control/internal/pkg/config"
 	"go.uber.org/zap"
@@ -13,28 +12,29 @@ import (
 
 type Logger struct {
 	*zap.SugaredLogger
+	level zap.AtomicLevel
 }
 
 const (
 	InfoLevel    = "info"
 	DebugLevel   = "debug"
+	WarnLevel    = "warn"
+	ErrorLevel   = "error"
 	JSONEncoding = "json"
 )
 
-func New(cfg config.Logger) (Logger, error) {
-	var logLvl zapcore.Level
+var ErrUnknownLevel = errors.New("unknown log level")
 
-	switch cfg.Level {
-	case InfoLevel:
-		logLvl = zap.InfoLevel
-	case DebugLevel:
-		logLvl = zap.DebugLevel
-	default:
-		log.Fatal("unexpected log level")
+func New(cfg config.Logger) (Logger, error) {
+	logLvl, err := parseLevel(cfg.Level)
+	if err != nil {
+		return Logger{}, err
 	}
 
+	level := zap.NewAtomicLevelAt(logLvl)
+
 	config := zap.Config{ //nolint:exhaustruct
-		Level:    zap.NewAtomicLevelAt(logLvl),
+		Level:    level,
 		Encoding: JSONEncoding,
 		EncoderConfig: zapcore.EncoderConfig{ //nolint:exhaustruct
 			MessageKey: "message",
@@ -54,30 +54,79 @@ func New(cfg config.Logger) (Logger, error) {
 		ErrorOutputPaths: append([]string{"stderr"}, cfg.ErrOutput...),
 	}
 
-	core, err := getCore(logLvl, config)
+	files, err := newRotatingFiles(cfg)
 	if err != nil {
 		return Logger{}, err
 	}
 
+	core, err := getCore(level, config, files)
+	if err != nil {
+		return Logger{}, err
+	}
+
+	if len(files.files) != 0 {
+		go files.run(notifyReopen())
+	}
+
+	if cfg.Sampling.Enabled {
+		core = zapcore.NewSamplerWithOptions(core, cfg.Sampling.Tick, cfg.Sampling.Initial, cfg.Sampling.Thereafter)
+	}
+
 	logg := zap.New(core, zap.AddCaller())
+	l := Logger{SugaredLogger: logg.Sugar(), level: level}
+
+	global.Store(l)
 
-	return Logger{logg.Sugar()}, nil
+	return l, nil
 }
 
-func getCore(logLvl zapcore.Level, config zap.Config) (zapcore.Core, error) { //nolint:ireturn
+// Level возвращает текущий уровень логирования.
+func (l Logger) Level() string {
+	return l.level.String()
+}
+
+// SetLevel меняет уровень логирования во время работы. Изменение действует
+// на все логгеры, полученные из этого через With и FromContext.
+func (l Logger) SetLevel(lvl string) error {
+	logLvl, err := parseLevel(lvl)
+	if err != nil {
+		return err
+	}
+
+	l.level.SetLevel(logLvl)
+
+	return nil
+}
+
+func parseLevel(lvl string) (zapcore.Level, error) {
+	switch lvl {
+	case DebugLevel:
+		return zap.DebugLevel, nil
+	case InfoLevel:
+		return zap.InfoLevel, nil
+	case WarnLevel:
+		return zap.WarnLevel, nil
+	case ErrorLevel:
+		return zap.ErrorLevel, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownLevel, lvl)
+	}
+}
+
+func getCore(level zap.AtomicLevel, config zap.Config, files *rotatingFiles) (zapcore.Core, error) { //nolint:ireturn
 	highPriority := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		return lvl >= zapcore.ErrorLevel
+		return lvl >= zapcore.ErrorLevel && level.Enabled(lvl)
 	})
 	levelEnabler := zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
-		return lvl < zap.ErrorLevel && lvl >= logLvl
+		return lvl < zap.ErrorLevel && level.Enabled(lvl)
 	})
 
-	ws, err := toMultiSyncer(config.OutputPaths)
+	ws, err := toMultiSyncer(config.OutputPaths, files)
 	if err != nil {
 		return nil, err
 	}
 
-	wsErr, err := toMultiSyncer(config.ErrorOutputPaths)
+	wsErr, err := toMultiSyncer(config.ErrorOutputPaths, files)
 	if err != nil {
 		return nil, err
 	}
@@ -90,23 +139,19 @@ func getCore(logLvl zapcore.Level, config zap.Config) (zapcore.Core, error) { //
 	return core, nil
 }
 
-func toMultiSyncer(files []string) (zapcore.WriteSyncer, error) { //nolint:ireturn
-	w := make([]zapcore.WriteSyncer, 0, len(files))
+func toMultiSyncer(paths []string, files *rotatingFiles) (zapcore.WriteSyncer, error) { //nolint:ireturn
+	w := make([]zapcore.WriteSyncer, 0, len(paths))
 
-	for _, f := range files {
-		switch f {
+	for _, p := range paths {
+		switch p {
 		case "stderr":
 			w = append(w, zapcore.AddSync(os.Stderr))
 		case "stdout":
 			w = append(w, zapcore.AddSync(os.Stdout))
 		default:
-			if err := os.MkdirAll(filepath.Dir(f), os.ModePerm); err != nil {
-				return nil, fmt.Errorf("mkdir error %w", err)
-			}
-
-			file, err := os.OpenFile(f, os.O_APPEND|os.O_CREATE|os.O_RDWR, os.ModePerm)
+			file, err := files.open(p)
 			if err != nil {
-				return nil, fmt.Errorf("open file error %w", err)
+				return nil, err
 			}
 
 			w = append(w, zapcore.AddSync(file))
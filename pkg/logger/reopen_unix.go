@@ -0,0 +1,18 @@
+//go:build !windows
+
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyReopen подписывается на SIGUSR1, которым внешний logrotate
+// просит открыть файлы логов заново.
+func notifyReopen() <-chan os.Signal {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR1)
+
+	return ch
+}
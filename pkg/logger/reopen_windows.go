@@ -0,0 +1,10 @@
+//go:build windows
+
+package logger
+
+import "os"
+
+// notifyReopen на Windows ничего не делает: SIGUSR1 там нет.
+func notifyReopen() <-chan os.Signal {
+	return nil
+}
@@ -0,0 +1,149 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Leopold1975/banners_control/internal/pkg/config"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	hoursInDay      = 24
+	defaultFileMode = 0o644
+)
+
+// rotatingFile пишет в файл с ротацией по размеру. Новые файлы создаются
+// с правами perm: lumberjack переносит права со старого файла на новый.
+type rotatingFile struct {
+	*lumberjack.Logger
+	perm os.FileMode
+}
+
+func newRotatingFile(path string, cfg config.LoggerRotation, perm os.FileMode) (*rotatingFile, error) {
+	f := &rotatingFile{
+		Logger: &lumberjack.Logger{ //nolint:exhaustruct
+			Filename:   path,
+			MaxSize:    cfg.MaxSize,
+			MaxAge:     int((cfg.MaxAge + hoursInDay*time.Hour - 1) / (hoursInDay * time.Hour)),
+			MaxBackups: cfg.MaxBackups,
+			Compress:   cfg.Compress,
+		},
+		perm: perm,
+	}
+
+	if err := f.create(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Reopen закрывает файл, чтобы следующая запись открыла его заново.
+// Нужен после того, как внешний logrotate переименовал файл.
+func (f *rotatingFile) Reopen() error {
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close log file error: %w", err)
+	}
+
+	return f.create()
+}
+
+func (f *rotatingFile) create() error {
+	if err := os.MkdirAll(filepath.Dir(f.Filename), os.ModePerm); err != nil {
+		return fmt.Errorf("mkdir error %w", err)
+	}
+
+	file, err := os.OpenFile(f.Filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, f.perm)
+	if err != nil {
+		return fmt.Errorf("open file error %w", err)
+	}
+
+	return file.Close() //nolint:wrapcheck
+}
+
+// rotatingFiles - открытые файлы логов. Один путь может быть указан
+// и в output, и в errOutput, но открывается один раз.
+type rotatingFiles struct {
+	mu    sync.Mutex
+	files map[string]*rotatingFile
+	cfg   config.LoggerRotation
+	perm  os.FileMode
+}
+
+func newRotatingFiles(cfg config.Logger) (*rotatingFiles, error) {
+	perm := uint64(defaultFileMode)
+
+	if cfg.FileMode != "" {
+		var err error
+
+		perm, err = strconv.ParseUint(cfg.FileMode, 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("parse file mode %q error: %w", cfg.FileMode, err)
+		}
+	}
+
+	return &rotatingFiles{
+		files: make(map[string]*rotatingFile),
+		cfg:   cfg.Rotation,
+		perm:  os.FileMode(perm),
+	}, nil
+}
+
+func (rf *rotatingFiles) open(path string) (*rotatingFile, error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if f, ok := rf.files[path]; ok {
+		return f, nil
+	}
+
+	f, err := newRotatingFile(path, rf.cfg, rf.perm)
+	if err != nil {
+		return nil, err
+	}
+
+	rf.files[path] = f
+
+	return f, nil
+}
+
+func (rf *rotatingFiles) each(fn func(*rotatingFile) error) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	for path, f := range rf.files {
+		if err := fn(f); err != nil {
+			fmt.Fprintf(os.Stderr, "log file %s error: %s\n", path, err.Error())
+		}
+	}
+}
+
+// run ротирует файлы раз в Interval и открывает их заново по сигналу reopen.
+func (rf *rotatingFiles) run(reopen <-chan os.Signal) {
+	var tick <-chan time.Time
+
+	if rf.cfg.Interval > 0 {
+		t := time.NewTicker(rf.cfg.Interval)
+		defer t.Stop()
+
+		tick = t.C
+	}
+
+	for {
+		select {
+		case <-tick:
+			rf.each(func(f *rotatingFile) error { return f.Rotate() }) //nolint:wrapcheck
+		case _, ok := <-reopen:
+			if !ok {
+				return
+			}
+
+			rf.each((*rotatingFile).Reopen)
+		}
+	}
+}
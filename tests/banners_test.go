@@ -1,11 +1,17 @@
 package tests
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,6 +19,7 @@ import (
 	"github.com/Leopold1975/banners_control/internal/banners/api/server"
 	"github.com/Leopold1975/banners_control/internal/banners/app"
 	"github.com/Leopold1975/banners_control/internal/banners/domain/models"
+	"github.com/Leopold1975/banners_control/internal/banners/services/webhookservice"
 	"github.com/Leopold1975/banners_control/internal/pkg/config"
 
 	"github.com/stretchr/testify/suite"
@@ -193,6 +200,21 @@ func (bs *BannerSuite) TestGetBanner() {
 	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
 
 	bs.Require().True(banners[0].Content["title"] == userBannerResp.Banner["title"] || banners[1].Content["title"] == userBannerResp.Banner["title"])
+	bs.Require().Contains(resp.Header.Get("Cache-Control"), "max-age=")
+
+	// Пользователь передает ETag полученного баннера, баннер не изменился
+	etag := resp.Header.Get("ETag")
+	bs.Require().NotEmpty(etag)
+
+	resp, err = bs.client.GetUserBanner(ctx, &oapi.GetUserBannerParams{
+		Token:       &respToken.Token,
+		FeatureId:   5,
+		TagId:       2,
+		IfNoneMatch: &etag,
+	})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusNotModified, resp.StatusCode)
+	resp.Body.Close()
 }
 
 func (bs *BannerSuite) TestOtherScenarios() {
@@ -277,6 +299,173 @@ func (bs *BannerSuite) TestOtherScenarios() {
 	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
 	bs.Require().Equal(3, len(bannersFromDB))
 
+	// Админ читает список постранично
+	limit, withTotal, order := 2, true, oapi.GetBannerParamsOrderDesc
+	resp, err = bs.client.GetBanner(ctx, &oapi.GetBannerParams{
+		Token:     &adminToken,
+		Limit:     &limit,
+		Order:     &order,
+		WithTotal: &withTotal,
+	})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusOK, resp.StatusCode)
+	bs.Require().Equal("3", resp.Header.Get("X-Total-Count"))
+
+	var firstPage, secondPage []models.Banner
+
+	dec = json.NewDecoder(resp.Body)
+	err = dec.Decode(&firstPage)
+	resp.Body.Close()
+
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(2, len(firstPage))
+	bs.Require().Greater(firstPage[0].ID, firstPage[1].ID)
+
+	nextCursor := resp.Header.Get("X-Next-Cursor")
+	bs.Require().NotEmpty(nextCursor)
+
+	resp, err = bs.client.GetBanner(ctx, &oapi.GetBannerParams{
+		Token:  &adminToken,
+		Limit:  &limit,
+		Order:  &order,
+		Cursor: &nextCursor,
+	})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusOK, resp.StatusCode)
+	bs.Require().Empty(resp.Header.Get("X-Next-Cursor"))
+
+	dec = json.NewDecoder(resp.Body)
+	err = dec.Decode(&secondPage)
+	resp.Body.Close()
+
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(1, len(secondPage))
+	bs.Require().Less(secondPage[0].ID, firstPage[1].ID)
+
+	// Курсор не подходит к другому порядку сортировки
+	resp, err = bs.client.GetBanner(ctx, &oapi.GetBannerParams{
+		Token:  &adminToken,
+		Limit:  &limit,
+		Cursor: &nextCursor,
+	})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	resp.Body.Close()
+	bs.Require().Equal(http.StatusBadRequest, resp.StatusCode)
+
+	// Админ фильтрует баннеры по содержимому
+	contentFilter := []string{"title=another title"}
+	resp, err = bs.client.GetBanner(ctx, &oapi.GetBannerParams{
+		Token:   &adminToken,
+		Content: &contentFilter,
+	})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	bannersFromDB = nil
+	dec = json.NewDecoder(resp.Body)
+	err = dec.Decode(&bannersFromDB)
+	resp.Body.Close()
+
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(1, len(bannersFromDB))
+	bs.Require().Equal(banners[1].Content["title"], bannersFromDB[0].Content["title"])
+
+	// Админ отбирает баннеры со всеми тэгами из списка и ищет по тексту
+	tagIDs, tagMatch := []int{2, 6}, oapi.GetBannerParamsTagMatchAll
+	resp, err = bs.client.GetBanner(ctx, &oapi.GetBannerParams{
+		Token:    &adminToken,
+		TagIds:   &tagIDs,
+		TagMatch: &tagMatch,
+	})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	bannersFromDB = nil
+	dec = json.NewDecoder(resp.Body)
+	err = dec.Decode(&bannersFromDB)
+	resp.Body.Close()
+
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(2, len(bannersFromDB))
+
+	search, active := "another", true
+	resp, err = bs.client.GetBanner(ctx, &oapi.GetBannerParams{
+		Token:    &adminToken,
+		Q:        &search,
+		IsActive: &active,
+	})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	bannersFromDB = nil
+	dec = json.NewDecoder(resp.Body)
+	err = dec.Decode(&bannersFromDB)
+	resp.Body.Close()
+
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(1, len(bannersFromDB))
+	bs.Require().Equal(banners[1].Content["title"], bannersFromDB[0].Content["title"])
+
+	// Админ выгружает баннеры и проверяет их загрузку без сохранения
+	resp, err = bs.client.GetBannerExport(ctx, &oapi.GetBannerExportParams{
+		Token: &adminToken,
+	})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusOK, resp.StatusCode)
+	bs.Require().Equal("application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	export, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(3, bytes.Count(export, []byte("\n")))
+
+	dryRun := true
+	resp, err = bs.client.PostBannerImportWithBody(ctx, &oapi.PostBannerImportParams{
+		Token:  &adminToken,
+		DryRun: &dryRun,
+	}, "application/x-ndjson", bytes.NewReader(export))
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	var report server.ImportResponse
+
+	dec = json.NewDecoder(resp.Body)
+	err = dec.Decode(&report)
+	resp.Body.Close()
+
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(3, report.Created)
+	bs.Require().True(report.DryRun)
+
+	// Ошибка в одной строке отменяет импорт целиком
+	broken := append(append([]byte{}, export...), []byte("{\"feature_id\": 0}\n")...)
+	resp, err = bs.client.PostBannerImportWithBody(ctx, &oapi.PostBannerImportParams{
+		Token: &adminToken,
+	}, "application/x-ndjson", bytes.NewReader(broken))
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+
+	report = server.ImportResponse{}
+	dec = json.NewDecoder(resp.Body)
+	err = dec.Decode(&report)
+	resp.Body.Close()
+
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(1, report.Failed)
+	bs.Require().Equal(4, report.Errors[0].Line)
+	bs.Require().Equal(0, report.Created)
+
+	// Некорректный jsonpath
+	badPath := "$.title ==="
+	resp, err = bs.client.GetBanner(ctx, &oapi.GetBannerParams{
+		Token:       &adminToken,
+		ContentPath: &badPath,
+	})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	resp.Body.Close()
+	bs.Require().Equal(http.StatusBadRequest, resp.StatusCode)
+
 	// Админ изменяет баннер
 	resp, err = bs.client.PatchBannerId(ctx, 3, &oapi.PatchBannerIdParams{
 		Token: &adminToken,
@@ -449,6 +638,324 @@ func (bs *BannerSuite) TestCreateUser() {
 	bs.Require().NotEqual("", createUserResp.Token)
 }
 
+func (bs *BannerSuite) TestUserBannerStream() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	adminToken := bs.login(ctx, adminUsername, adminPassword)
+	userToken := bs.login(ctx, defaultUserUsername, defaultUserPassword)
+
+	// Пользователь подписывается и сразу получает текущий баннер
+	resp, err := bs.client.GetUserBannerStream(ctx, &oapi.GetUserBannerStreamParams{
+		Token:     &userToken,
+		FeatureId: 5,
+		TagId:     1,
+	})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusOK, resp.StatusCode)
+	bs.Require().Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := bufio.NewReader(resp.Body)
+
+	first := readStreamEvent(bs, events)
+	bs.Require().Equal("banner", first.name)
+
+	var data server.StreamBanner
+	bs.Require().NoError(json.Unmarshal([]byte(first.data), &data))
+	bs.Require().Equal(int64(1), data.BannerID)
+
+	// Админ меняет баннер, пользователь получает новое содержимое
+	active := true
+	content := map[string]interface{}{"title": "streamed title"}
+	resp2, err := bs.client.PatchBannerId(ctx, 1, &oapi.PatchBannerIdParams{
+		Token: &adminToken,
+	}, oapi.PatchBannerIdJSONRequestBody(
+		oapi.PatchBannerIdJSONBody{
+			Content:   &content,
+			FeatureId: &banners[0].FeatureID,
+			TagIds:    &banners[0].Tags,
+			IsActive:  &active,
+		},
+	))
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusOK, resp2.StatusCode)
+	resp2.Body.Close()
+
+	updated := readStreamEvent(bs, events)
+	bs.Require().Equal("updated", updated.name)
+	bs.Require().NoError(json.Unmarshal([]byte(updated.data), &data))
+	bs.Require().Equal("streamed title", data.Content["title"])
+	resp.Body.Close()
+
+	// После переподключения с Last-Event-ID пропущенное изменение приходит снова
+	resp, err = bs.client.GetUserBannerStream(ctx, &oapi.GetUserBannerStreamParams{
+		Token:       &userToken,
+		FeatureId:   5,
+		TagId:       1,
+		LastEventID: &first.id,
+	})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	replayed := readStreamEvent(bs, bufio.NewReader(resp.Body))
+	resp.Body.Close()
+
+	bs.Require().Equal(updated, replayed)
+}
+
+func (bs *BannerSuite) TestWebhooks() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	adminToken := bs.login(ctx, adminUsername, adminPassword)
+
+	type delivery struct {
+		event   string
+		payload map[string]interface{}
+	}
+
+	received := make(chan delivery, 10)
+	secret := "webhook secret"
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		bs.Require().NoError(err)
+
+		ts, err := strconv.ParseInt(r.Header.Get(webhookservice.HeaderTimestamp), 10, 64)
+		bs.Require().NoError(err)
+		bs.Require().Equal(webhookservice.Sign(secret, ts, body), r.Header.Get(webhookservice.HeaderSignature))
+
+		var payload map[string]interface{}
+		bs.Require().NoError(json.Unmarshal(body, &payload))
+
+		received <- delivery{r.Header.Get(webhookservice.HeaderEvent), payload}
+	}))
+	defer receiver.Close()
+
+	// Админ подписывается на создание, изменения и включение баннеров фичи 3
+	resp, err := bs.client.PostWebhooks(ctx, &oapi.PostWebhooksParams{Token: &adminToken},
+		oapi.PostWebhooksJSONRequestBody{
+			Url:        receiver.URL,
+			Events:     []oapi.PostWebhooksJSONBodyEvents{oapi.Created, oapi.Updated, oapi.Activated},
+			FeatureIds: &[]int{3},
+			Secret:     &secret,
+		})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusCreated, resp.StatusCode)
+
+	var webhook models.Webhook
+	bs.Require().NoError(json.NewDecoder(resp.Body).Decode(&webhook))
+	resp.Body.Close()
+
+	// Баннер 3 выключается и снова включается, баннер 1 другой фичи не отслеживается
+	patch := func(id int, b models.Banner, active bool) {
+		resp, err := bs.client.PatchBannerId(ctx, id, &oapi.PatchBannerIdParams{
+			Token: &adminToken,
+		}, oapi.PatchBannerIdJSONRequestBody(
+			oapi.PatchBannerIdJSONBody{
+				Content:   &b.Content,
+				FeatureId: &b.FeatureID,
+				TagIds:    &b.Tags,
+				IsActive:  &active,
+			},
+		))
+		bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+		bs.Require().Equal(http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	patch(3, banners[2], false)
+	patch(1, banners[0], true)
+	patch(3, banners[2], true)
+
+	for _, want := range []string{"updated", "updated", "activated"} {
+		select {
+		case d := <-received:
+			bs.Require().Equal(want, d.event)
+			bs.Require().Equal(want, d.payload["event"])
+			bs.Require().Equal(float64(3), d.payload["banner"].(map[string]interface{})["banner_id"])
+		case <-ctx.Done():
+			bs.T().Fatalf("webhook %s not delivered", want)
+		}
+	}
+
+	// Журнал доставок, новые первыми
+	var deliveries []server.DeliveryResponse
+
+	bs.Require().Eventually(func() bool {
+		resp, err := bs.client.GetWebhooksIdDeliveries(ctx, webhook.ID, &oapi.GetWebhooksIdDeliveriesParams{
+			Token: &adminToken,
+		})
+		bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+		bs.Require().Equal(http.StatusOK, resp.StatusCode)
+		defer resp.Body.Close()
+
+		bs.Require().NoError(json.NewDecoder(resp.Body).Decode(&deliveries))
+
+		return len(deliveries) == 3 && deliveries[0].Status == models.DeliveryDelivered
+	}, time.Second*2, time.Millisecond*100)
+
+	bs.Require().Equal("activated", deliveries[0].Event)
+	bs.Require().Equal(http.StatusOK, deliveries[0].ResponseCode)
+
+	// Импорт создает выключенный баннер, повторный импорт с upsert включает его
+	upsert := true
+	imported := models.Banner{
+		FeatureID:   3,
+		Tags:        []int{7},
+		Active:      false,
+		Content:     map[string]interface{}{"title": "imported title"},
+		ExternalKey: "webhook-import",
+	}
+	importBanner := func() {
+		line, err := json.Marshal(imported)
+		bs.Require().NoError(err)
+
+		resp, err := bs.client.PostBannerImportWithBody(ctx, &oapi.PostBannerImportParams{
+			Token:  &adminToken,
+			Upsert: &upsert,
+		}, "application/x-ndjson", bytes.NewReader(append(line, '\n')))
+		bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+		bs.Require().Equal(http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	importBanner()
+
+	imported.Active = true
+	importBanner()
+
+	for _, want := range []string{"created", "updated", "activated"} {
+		select {
+		case d := <-received:
+			bs.Require().Equal(want, d.event)
+
+			banner := d.payload["banner"].(map[string]interface{})
+			bs.Require().Equal("webhook-import", banner["external_key"])
+			bs.Require().Equal(want != "created", banner["is_active"])
+
+			if want != "created" {
+				bs.Require().Equal(false, d.payload["previous"].(map[string]interface{})["is_active"])
+			}
+		case <-ctx.Done():
+			bs.T().Fatalf("webhook %s for import not delivered", want)
+		}
+	}
+
+	resp, err = bs.client.DeleteWebhooksId(ctx, webhook.ID, &oapi.DeleteWebhooksIdParams{Token: &adminToken})
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func (bs *BannerSuite) TestBannerStats() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	adminToken := bs.login(ctx, adminUsername, adminPassword)
+	userToken := bs.login(ctx, defaultUserUsername, defaultUserPassword)
+
+	// Каждый ответ /user_banner учитывается как показ
+	var bannerID string
+
+	for range 2 {
+		resp, err := bs.client.GetUserBanner(ctx, &oapi.GetUserBannerParams{
+			Token:     &userToken,
+			FeatureId: 5,
+			TagId:     1,
+		})
+		bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+		bs.Require().Equal(http.StatusOK, resp.StatusCode)
+		resp.Body.Close()
+
+		bannerID = resp.Header.Get("X-Banner-Id")
+	}
+
+	id, err := strconv.ParseInt(bannerID, 10, 64)
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+
+	resp, err := bs.client.PostEventsClick(ctx, &oapi.PostEventsClickParams{Token: &userToken},
+		oapi.PostEventsClickJSONRequestBody(oapi.PostEventsClickJSONBody{BannerId: id, TagId: 1}))
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	// Счетчики попадают в banner_stats через stats.flushInterval
+	var stats models.BannerStats
+
+	bs.Require().Eventually(func() bool {
+		resp, err := bs.client.GetBannerIdStats(ctx, int(id), &oapi.GetBannerIdStatsParams{Token: &adminToken})
+		bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+		bs.Require().Equal(http.StatusOK, resp.StatusCode)
+
+		defer resp.Body.Close()
+
+		bs.Require().NoError(json.NewDecoder(resp.Body).Decode(&stats))
+
+		return stats.Impressions >= 2 && stats.Clicks >= 1
+	}, time.Second*3, time.Millisecond*100)
+
+	bs.Require().Equal(id, stats.BannerID)
+	bs.Require().Greater(stats.CTR, 0.0)
+	bs.Require().NotEmpty(stats.Tags)
+	bs.Require().Equal(1, stats.Tags[0].TagID)
+
+	resp, err = bs.client.PostEventsClick(ctx, &oapi.PostEventsClickParams{Token: &userToken},
+		oapi.PostEventsClickJSONRequestBody(oapi.PostEventsClickJSONBody{BannerId: 0, TagId: 1}))
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func (bs *BannerSuite) login(ctx context.Context, username, password string) string {
+	resp, err := bs.client.PostAuth(ctx, oapi.PostAuthJSONRequestBody(
+		oapi.PostAuthJSONBody{
+			Username: &username,
+			Password: &password,
+		},
+	))
+	bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+	bs.Require().Equal(http.StatusOK, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	var respToken server.AuthUserResponse
+	bs.Require().NoError(json.NewDecoder(resp.Body).Decode(&respToken))
+
+	return respToken.Token
+}
+
+type streamEvent struct {
+	id, name, data string
+}
+
+// readStreamEvent читает следующее событие SSE, пропуская комментарии и retry.
+func readStreamEvent(bs *BannerSuite, r *bufio.Reader) streamEvent {
+	var e streamEvent
+
+	for {
+		line, err := r.ReadString('\n')
+		bs.Require().NoError(err, "expected %v	actual %v", nil, err)
+
+		line = strings.TrimSuffix(line, "\n")
+
+		field, value, _ := strings.Cut(line, ": ")
+
+		switch field {
+		case "id":
+			e.id = value
+		case "event":
+			e.name = value
+		case "data":
+			e.data = value
+		case "":
+			if e.name != "" {
+				return e
+			}
+		}
+	}
+}
+
 func TestBannerServiceSuite(t *testing.T) {
 	suite.Run(t, new(BannerSuite))
 }
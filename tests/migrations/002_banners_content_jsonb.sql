@@ -0,0 +1,11 @@
+-- +goose up
+ALTER TABLE banners ALTER COLUMN content TYPE jsonb USING content::jsonb;
+
+CREATE INDEX IF NOT EXISTS banners_content_idx ON banners USING GIN (content jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS banners_tag_ids_idx ON banners USING GIN (tag_ids);
+
+-- +goose down
+DROP INDEX IF EXISTS banners_tag_ids_idx;
+DROP INDEX IF EXISTS banners_content_idx;
+
+ALTER TABLE banners ALTER COLUMN content TYPE text USING content::text;
@@ -0,0 +1,5 @@
+-- +goose up
+CREATE INDEX IF NOT EXISTS banners_content_search_idx ON banners USING GIN (to_tsvector('simple', content));
+
+-- +goose down
+DROP INDEX IF EXISTS banners_content_search_idx;
@@ -0,0 +1,38 @@
+-- +goose up
+CREATE TABLE IF NOT EXISTS webhooks (
+    id SERIAL primary key,
+    url text not null,
+    secret text not null,
+    events text[] not null,
+    feature_ids int[] not null DEFAULT '{}',
+    created_at timestamptz not null DEFAULT current_timestamp
+);
+
+-- Исходящие события вебхуков: строки пишутся в транзакции изменения баннера
+-- и служат журналом доставки.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL primary key,
+    webhook_id int not null REFERENCES webhooks (id) ON DELETE CASCADE,
+    event text not null,
+    banner_id bigint not null,
+    payload jsonb not null,
+    status text not null DEFAULT 'pending',
+    attempts int not null DEFAULT 0,
+    next_attempt_at timestamptz not null DEFAULT current_timestamp,
+    response_code int,
+    last_error text,
+    created_at timestamptz not null DEFAULT current_timestamp,
+    delivered_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_next_idx ON webhook_deliveries (next_attempt_at)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_order_idx ON webhook_deliveries (webhook_id, banner_id, id)
+    WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_log_idx ON webhook_deliveries (webhook_id, id);
+
+-- +goose down
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;